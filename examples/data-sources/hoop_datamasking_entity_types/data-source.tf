@@ -0,0 +1,17 @@
+# Lists the entity types supported by the DLP provider of the gateway
+data "hoop_datamasking_entity_types" "all" {}
+
+# Masks every supported entity type in the sessions of the connection
+resource "hoop_datamasking_rules" "all" {
+  name                = "all-entities"
+  description         = "Masks every entity type supported by the gateway"
+  score_threshold     = 0.6
+  connection_ids      = ["c2f81d5c-8d08-4416-9205-4b88993c6ce7"]
+  custom_entity_types = []
+  supported_entity_types = [
+    {
+      name         = "ALL"
+      entity_types = data.hoop_datamasking_entity_types.all.entity_types
+    }
+  ]
+}
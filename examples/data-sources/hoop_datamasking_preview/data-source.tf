@@ -0,0 +1,28 @@
+# Previews the masking of an existing rule
+data "hoop_datamasking_preview" "pii" {
+  text    = "Contact John Doe at john.doe@example.com"
+  rule_id = hoop_datamasking_rules.rule1.id
+}
+
+# Previews custom entity types before adding them to a rule
+data "hoop_datamasking_preview" "account_id" {
+  text            = "The account ACC-12345678 was closed"
+  score_threshold = 0.6
+
+  custom_entity_types = [
+    {
+      name      = "ACCOUNT_ID"
+      regex     = "ACC-\\d{8}"
+      deny_list = []
+      score     = 0.9
+    }
+  ]
+}
+
+# Asserts the masking behaviour when planning
+check "account_id_masking" {
+  assert {
+    condition     = !strcontains(data.hoop_datamasking_preview.account_id.redacted_text, "ACC-12345678")
+    error_message = "The account id is not masked: ${data.hoop_datamasking_preview.account_id.redacted_text}"
+  }
+}
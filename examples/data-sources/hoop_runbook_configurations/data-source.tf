@@ -0,0 +1,17 @@
+# Lists every repository with a runbook configuration
+data "hoop_runbook_configurations" "all" {}
+
+# Lists the runbooks of each configured repository
+data "hoop_runbooks" "all" {
+  for_each   = { for repo in data.hoop_runbook_configurations.all.repositories : repo.repository => repo }
+  repository = each.key
+}
+
+# Grants the runbooks of every configured repository to the platform team
+resource "hoop_runbook_rule" "platform" {
+  name        = "platform"
+  description = "All runbooks of the configured repositories"
+  connections = ["pgdemo"]
+  user_groups = ["platform"]
+  runbooks    = flatten([for repo in data.hoop_runbooks.all : repo.runbooks])
+}
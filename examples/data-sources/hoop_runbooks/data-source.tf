@@ -0,0 +1,14 @@
+# Lists the runbooks of a configured repository
+data "hoop_runbooks" "postgres-demo" {
+  repository = hoop_runbook_configuration.hoop-public-runbooks.repository
+  glob       = "postgres-demo/*.runbook.sql"
+}
+
+# The runbooks could be assigned directly to a runbook rule
+resource "hoop_runbook_rule" "postgres-demo" {
+  name        = "postgres-demo"
+  description = "Runbooks of the postgres demo"
+  connections = ["pgdemo"]
+  user_groups = ["dba"]
+  runbooks    = data.hoop_runbooks.postgres-demo.runbooks
+}
@@ -0,0 +1,17 @@
+# Copyright (c) HashiCorp, Inc.
+
+# Short-lived credentials to access a database connection natively.
+# The values are not persisted in the plan or state.
+ephemeral "hoop_connection_credentials" "pgdemo" {
+  connection              = "pgdemo"
+  access_duration_seconds = 900
+}
+
+provider "postgresql" {
+  host     = ephemeral.hoop_connection_credentials.pgdemo.host
+  port     = ephemeral.hoop_connection_credentials.pgdemo.port
+  username = ephemeral.hoop_connection_credentials.pgdemo.user
+  password = ephemeral.hoop_connection_credentials.pgdemo.password
+  database = ephemeral.hoop_connection_credentials.pgdemo.database_name
+  sslmode  = "disable"
+}
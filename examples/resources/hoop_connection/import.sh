@@ -1,3 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
-terraform import hoop_connection.bash bash-console
\ No newline at end of file
+# import by name
+terraform import hoop_connection.bash bash-console
+
+# import by ID
+terraform import hoop_connection.bash 6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1
@@ -47,3 +47,61 @@ resource "hoop_connection" "bash" {
   }
 }
 
+# Secrets managed only by their fingerprints (requires Terraform 1.11+).
+# The values are never stored in the state, drift is detected by comparing
+# the SHA-256 fingerprint of the configured value with the one in the gateway.
+resource "hoop_connection" "postgres" {
+  name     = "postgres-prod"
+  type     = "database"
+  subtype  = "postgres"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  secrets_wo = {
+    "envvar:HOST" = "pg-prod.internal"
+    "envvar:PORT" = "5432"
+    "envvar:USER" = "pguser"
+    "envvar:PASS" = "your-password"
+    "envvar:DB"   = "prod"
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "enabled"
+}
+
+# Connection with an approval policy
+resource "hoop_connection" "pgprod" {
+  name     = "pgprod"
+  type     = "database"
+  subtype  = "postgres"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  secrets = {
+    "envvar:HOST" = "pg-prod.internal"
+    "envvar:PORT" = "5432"
+    "envvar:USER" = "pguser"
+    "envvar:PASS" = "your-password"
+    "envvar:DB"   = "prod"
+  }
+
+  review = {
+    approver_groups = ["dba", "sre"]
+    # requires approvals from two distinct reviewers
+    min_approvals = 2
+    # one off executions and native access are reviewed separately
+    exec_review_required     = true
+    connect_review_required  = true
+    jit_max_duration_seconds = 3600
+    time_window = {
+      start_time = "09:00"
+      end_time   = "18:00"
+      timezone   = "America/Sao_Paulo"
+    }
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "enabled"
+}
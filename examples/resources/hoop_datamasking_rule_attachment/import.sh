@@ -0,0 +1,3 @@
+# Copyright (c) HashiCorp, Inc.
+
+terraform import hoop_datamasking_rule_attachment.payments 30ebf0cd-040e-4c89-9d6e-28f03f472a41/5001a4a4-9cba-4f2a-9147-d763cd070e0a
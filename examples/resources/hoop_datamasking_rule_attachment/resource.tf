@@ -0,0 +1,21 @@
+# Copyright (c) HashiCorp, Inc.
+
+# Data Masking Rule owned by the security team, the connections are attached by the application teams.
+resource "hoop_datamasking_rules" "shared" {
+  name                = "Shared PII Rule"
+  description         = "Masks the PII of every connection attached to it."
+  score_threshold     = 0.6
+  custom_entity_types = []
+  supported_entity_types = [
+    {
+      name         = "PII"
+      entity_types = ["EMAIL_ADDRESS", "PHONE_NUMBER"]
+    }
+  ]
+}
+
+# Attaches a connection of an application team to the shared rule.
+resource "hoop_datamasking_rule_attachment" "payments" {
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = hoop_connection.payments-db.id
+}
@@ -1,3 +1,7 @@
 # Copyright (c) HashiCorp, Inc.
 
-terraform import hoop_datamasking_rules.rule1 30ebf0cd-040e-4c89-9d6e-28f03f472a41
\ No newline at end of file
+# import by the id
+terraform import hoop_datamasking_rules.rule1 30ebf0cd-040e-4c89-9d6e-28f03f472a41
+
+# import by the name
+terraform import hoop_datamasking_rules.rule1 "name:Example Rule 1"
@@ -1 +1,5 @@
-terraform import hoop_runbook_configuration.myrepo https://github.com/hoophq/runbooks.git
\ No newline at end of file
+# import by the git url
+terraform import hoop_runbook_configuration.myrepo https://github.com/hoophq/runbooks.git
+
+# import by the normalized repository name
+terraform import hoop_runbook_configuration.myrepo github.com/hoophq/runbooks
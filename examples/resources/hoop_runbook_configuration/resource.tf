@@ -1,37 +1,33 @@
-
 # Runbooks configuration. Public Repositories
 resource "hoop_runbook_configuration" "hoop-public-runbooks" {
-  git_url         = "https://github.com/hoophq/runbooks.git"
-  git_hook_ttl    = 0
-  git_user        = ""
-  git_password    = ""
-  ssh_user        = ""
-  ssh_key         = ""
-  ssh_keypass     = ""
-  ssh_known_hosts = ""
+  git_url = "https://github.com/hoophq/runbooks.git"
 }
 
 
 # Runbooks configuration. Basic Credentials
-resource "hoop_runbook_configuration" "hoop-public-runbooks" {
-  git_url         = "https://github.com/your-org/your-repo"
-  git_hook_ttl    = 0
-  git_user        = "oauth2"
-  git_password    = "your-personal-access-token"
-  ssh_user        = ""
-  ssh_key         = ""
-  ssh_keypass     = ""
-  ssh_known_hosts = ""
+resource "hoop_runbook_configuration" "hoop-private-runbooks" {
+  git_url = "https://github.com/your-org/your-repo"
+
+  basic_auth {
+    username = "oauth2"
+    password = "your-personal-access-token"
+  }
 }
 
 # Runbooks configuration. SSH Private Keys
-resource "hoop_runbook_configuration" "hoop-public-runbooks" {
-  git_url         = "git@github.com:your-org/your-repo.git"
-  git_hook_ttl    = 0
-  git_user        = ""
-  git_password    = ""
-  ssh_user        = ""
-  ssh_key         = file("${path.module}/ssh_key.pem")
-  ssh_keypass     = "your-ssh-key-passphrase"
-  ssh_known_hosts = file("${path.module}/known_hosts")
+resource "hoop_runbook_configuration" "hoop-ssh-runbooks" {
+  git_url = "git@github.com:your-org/your-repo.git"
+
+  ssh_auth {
+    private_key            = file("${path.module}/ssh_key.pem")
+    private_key_passphrase = "your-ssh-key-passphrase"
+    known_hosts            = file("${path.module}/known_hosts")
+  }
+}
+
+# Runbooks configuration. Pinned to a tag, serving only the runbooks under ops/
+resource "hoop_runbook_configuration" "hoop-pinned-runbooks" {
+  git_url     = "https://github.com/hoophq/runbooks.git"
+  git_ref     = "v1.2.0"
+  path_prefix = "ops/"
 }
@@ -1 +1,5 @@
-terraform import hoop_runbook_rule.myrule 3f3cb338-b812-4e31-b6c2-c79a08cc3221
\ No newline at end of file
+# import by the id
+terraform import hoop_runbook_rule.myrule 3f3cb338-b812-4e31-b6c2-c79a08cc3221
+
+# import by the name
+terraform import hoop_runbook_rule.myrule "name:My Rule"
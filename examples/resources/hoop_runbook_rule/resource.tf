@@ -19,3 +19,23 @@ resource "hoop_runbook_rule" "myrule" {
     }
   ]
 }
+
+# grant every runbook of a directory, new files are granted without changing the rule
+resource "hoop_runbook_rule" "postgres-demo" {
+  name        = "Postgres Demo"
+  description = "All runbooks of the postgres demo"
+  connections = ["pgdemo"]
+  user_groups = ["dba"]
+  runbooks = [
+    {
+      repository = hoop_runbook_configuration.hoop-public-runbooks.repository
+      name       = "postgres-demo/"
+      match      = "prefix"
+    },
+    {
+      repository = hoop_runbook_configuration.hoop-public-runbooks.repository
+      name       = "ops/*.runbook.sh"
+      match      = "glob"
+    }
+  ]
+}
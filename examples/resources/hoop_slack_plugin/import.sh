@@ -0,0 +1,4 @@
+# Copyright (c) HashiCorp, Inc.
+
+# the connections of the plugin are not imported
+terraform import hoop_slack_plugin.slack slack
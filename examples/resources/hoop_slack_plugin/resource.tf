@@ -0,0 +1,17 @@
+# Copyright (c) HashiCorp, Inc.
+
+# Slack plugin sending the reviews of the connections to Slack channels.
+resource "hoop_slack_plugin" "slack" {
+  bot_token        = var.slack_bot_token # xoxb-...
+  app_token        = var.slack_app_token # xapp-...
+  default_channels = ["C082KCG5NJU"]
+
+  connections = [
+    # sends the reviews to the default channels
+    { connection_id = hoop_connection.pgdemo.id },
+    {
+      connection_id = hoop_connection.payments-db.id
+      channels      = ["C07PAYMENTS", "G01SECURITY"]
+    }
+  ]
+}
@@ -3,6 +3,7 @@
 package hoop
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,9 +15,10 @@ type HttpClient interface {
 }
 
 type Client struct {
-	apiURL     string
-	token      string
-	httpClient HttpClient
+	apiURL            string
+	token             string
+	httpClient        HttpClient
+	optimisticLocking bool
 }
 
 func NewClient(apiURL, token string, httpClient HttpClient) *Client {
@@ -24,7 +26,44 @@ func NewClient(apiURL, token string, httpClient HttpClient) *Client {
 		httpClient = http.DefaultClient
 	}
 	apiURL = strings.TrimSuffix(apiURL, "/")
-	return &Client{apiURL: apiURL, token: token, httpClient: httpClient}
+	return &Client{apiURL: apiURL, token: token, httpClient: httpClient, optimisticLocking: true}
+}
+
+// SetOptimisticLocking enables or disables sending the If-Match header
+// on requests that change resources. It's enabled by default.
+func (c *Client) SetOptimisticLocking(enabled bool) {
+	c.optimisticLocking = enabled
+}
+
+// setIfMatch sets the If-Match header when the optimistic locking
+// is enabled and the version of the resource is known.
+func (c *Client) setIfMatch(req *http.Request, etag string) {
+	if c.optimisticLocking && etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+}
+
+// APIError is returned when the gateway responds with an unexpected status code.
+type APIError struct {
+	StatusCode int
+	Payload    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status=%v, payload=%v", e.StatusCode, e.Payload)
+}
+
+// IsPreconditionFailed reports whether the resource has changed
+// since the version sent in the If-Match header.
+func IsPreconditionFailed(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// IsNotFound reports whether the resource does not exist in the gateway.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
 }
 
 func validateErr(resp *http.Response) error {
@@ -33,5 +72,5 @@ func validateErr(resp *http.Response) error {
 		return fmt.Errorf("failed reading response body, status=%v, reason=%v",
 			resp.StatusCode, err)
 	}
-	return fmt.Errorf("status=%v, payload=%v", resp.StatusCode, string(data))
+	return &APIError{StatusCode: resp.StatusCode, Payload: string(data)}
 }
@@ -7,8 +7,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"reflect"
+	"strings"
+	"unicode/utf8"
 )
 
 type Connection struct {
@@ -20,6 +22,7 @@ type Connection struct {
 	Secrets             map[string]string `json:"secret"`
 	AgentId             string            `json:"agent_id"`
 	Reviewers           []string          `json:"reviewers"`
+	Review              *ConnectionReview `json:"review"`
 	RedactEnabled       bool              `json:"redact_enabled"`
 	RedactTypes         []string          `json:"redact_types"`
 	ConnectionTags      map[string]string `json:"connection_tags"`
@@ -29,10 +32,84 @@ type Connection struct {
 	AccessSchema        string            `json:"access_schema"`
 	GuardRailRules      []string          `json:"guardrail_rules"`
 	JiraIssueTemplateID string            `json:"jira_issue_template_id"`
+
+	// SecretFiles are binary-safe filesystem secrets keyed by name, they are sent to
+	// the gateway as the secret filesystem:<name>. Filesystem secrets that are not
+	// valid UTF-8 text are decoded into this field instead of Secrets.
+	SecretFiles map[string][]byte `json:"-"`
+
+	// ETag is the version of the connection returned by the gateway,
+	// it's sent in the If-Match header when updating or deleting it.
+	ETag string `json:"-"`
+
+	// Extra holds the fields returned by the gateway that are not modeled by this type.
+	// They are sent back as they are, preventing updates from erasing them.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// SecretFilePrefix is the prefix of the secrets mounted as files by the agent.
+const SecretFilePrefix = "filesystem:"
+
+// connectionFields are the json fields modeled by the Connection type
+var connectionFields = jsonFieldNames(reflect.TypeOf(Connection{}))
+
+func (c *Connection) UnmarshalJSON(data []byte) error {
+	type connection Connection
+	if err := json.Unmarshal(data, (*connection)(c)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, name := range connectionFields {
+		delete(fields, name)
+	}
+	c.Extra = nil
+	if len(fields) > 0 {
+		c.Extra = fields
+	}
+	return nil
+}
+
+func (c Connection) MarshalJSON() ([]byte, error) {
+	type connection Connection
+	data, err := json.Marshal(connection(c))
+	if err != nil || len(c.Extra) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, val := range c.Extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = val
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// ConnectionReview is the approval policy applied to sessions of a connection.
+type ConnectionReview struct {
+	ApproverGroups        []string                `json:"approver_groups"`
+	MinApprovals          int64                   `json:"min_approvals"`
+	ExecReviewRequired    bool                    `json:"exec_review_required"`
+	ConnectReviewRequired bool                    `json:"connect_review_required"`
+	JitMaxDurationSec     int64                   `json:"jit_max_duration_sec"`
+	TimeWindow            *ConnectionReviewWindow `json:"time_window"`
+}
+
+// ConnectionReviewWindow is the daily period in which a reviewed access could be used.
+type ConnectionReviewWindow struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Timezone  string `json:"timezone"`
 }
 
-func (c *Client) GetConnection(name string) (*Connection, error) {
-	apiURL := fmt.Sprintf("%s/connections/%s", c.apiURL, name)
+// GetConnection fetches a connection by its name or ID.
+func (c *Client) GetConnection(nameOrID string) (*Connection, error) {
+	apiURL := fmt.Sprintf("%s/connections/%s", c.apiURL, nameOrID)
 
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -45,7 +122,7 @@ func (c *Client) GetConnection(name string) (*Connection, error) {
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusOK {
-		return decodeConnection(resp.Body)
+		return decodeConnection(resp)
 	}
 	return nil, validateErr(resp)
 }
@@ -69,43 +146,72 @@ func (c *Client) CreateConnection(conn Connection) (*Connection, error) {
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusCreated {
-		return decodeConnection(resp.Body)
+		return decodeConnection(resp)
 	}
 	return nil, validateErr(resp)
 }
 
-func (c *Client) UpdateConnection(conn Connection) (*Connection, error) {
+// UpdateConnection replaces the connection attributes keeping the fields
+// of the current connection that are not modeled by the Connection type.
+// The connection is addressed by its ID when it's set, allowing to rename it.
+// When merge is not nil, it's called with the current connection to carry
+// over attributes of the current connection before sending the update.
+func (c *Client) UpdateConnection(conn Connection, merge func(current, conn *Connection)) (*Connection, error) {
+	nameOrID := conn.ID
+	if nameOrID == "" {
+		nameOrID = conn.Name
+	}
+	current, err := c.GetConnection(nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current connection, reason=%w", err)
+	}
+	for name, val := range current.Extra {
+		if _, ok := conn.Extra[name]; ok {
+			continue
+		}
+		if conn.Extra == nil {
+			conn.Extra = map[string]json.RawMessage{}
+		}
+		conn.Extra[name] = val
+	}
+	if merge != nil {
+		merge(current, &conn)
+	}
+
 	body, err := encodeConnection(conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal connection, reason=%v", err)
 	}
 
-	apiURL := fmt.Sprintf("%s/connections/%s", c.apiURL, conn.Name)
+	apiURL := fmt.Sprintf("%s/connections/%s", c.apiURL, nameOrID)
 	req, err := http.NewRequest("PUT", apiURL, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request, reason=%v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Api-Key", c.token)
+	c.setIfMatch(req, conn.ETag)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection, reason=%v", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusOK {
-		return decodeConnection(resp.Body)
+		return decodeConnection(resp)
 	}
 	return nil, validateErr(resp)
 }
 
-func (c *Client) DeleteConnection(name string) error {
-	apiURL := fmt.Sprintf("%s/connections/%s", c.apiURL, name)
+// DeleteConnection removes a connection by its name or ID.
+func (c *Client) DeleteConnection(nameOrID, etag string) error {
+	apiURL := fmt.Sprintf("%s/connections/%s", c.apiURL, nameOrID)
 
 	req, err := http.NewRequest("DELETE", apiURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request, reason=%v", err)
 	}
 	req.Header.Set("Api-Key", c.token)
+	c.setIfMatch(req, etag)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete connection, reason=%v", err)
@@ -117,9 +223,9 @@ func (c *Client) DeleteConnection(name string) error {
 	return validateErr(resp)
 }
 
-func decodeConnection(responseBody io.Reader) (*Connection, error) {
+func decodeConnection(resp *http.Response) (*Connection, error) {
 	var conn Connection
-	err := json.NewDecoder(responseBody).Decode(&conn)
+	err := json.NewDecoder(resp.Body).Decode(&conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed decoding connection resource, reason=%v", err)
 	}
@@ -129,9 +235,17 @@ func decodeConnection(responseBody io.Reader) (*Connection, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to decode secret %q, reason=%v", key, err)
 		}
+		if name, ok := strings.CutPrefix(key, SecretFilePrefix); ok && !utf8.Valid(decVal) {
+			if conn.SecretFiles == nil {
+				conn.SecretFiles = map[string][]byte{}
+			}
+			conn.SecretFiles[name] = decVal
+			continue
+		}
 		secrets[key] = string(decVal)
 	}
 	conn.Secrets = secrets
+	conn.ETag = resp.Header.Get("ETag")
 	return &conn, nil
 }
 
@@ -140,6 +254,33 @@ func encodeConnection(conn Connection) ([]byte, error) {
 	for key, val := range conn.Secrets {
 		secrets[key] = base64.StdEncoding.EncodeToString([]byte(val))
 	}
+	for name, content := range conn.SecretFiles {
+		key := SecretFilePrefix + name
+		if _, ok := secrets[key]; ok {
+			return nil, fmt.Errorf("secret %q is set as a secret and as a secret file", key)
+		}
+		secrets[key] = base64.StdEncoding.EncodeToString(content)
+	}
 	conn.Secrets = secrets
 	return json.Marshal(conn)
 }
+
+// jsonFieldNames returns the json field names of the exported fields of a struct type
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
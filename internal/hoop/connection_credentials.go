@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+
+package hoop
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type ConnectionCredentials struct {
+	ID                    string                     `json:"id"`
+	ConnectionName        string                     `json:"connection_name"`
+	ConnectionType        string                     `json:"connection_type"`
+	ConnectionSubType     string                     `json:"connection_subtype"`
+	ConnectionCredentials NativeConnectionCredential `json:"connection_credentials"`
+	CreatedAt             time.Time                  `json:"created_at"`
+	ExpireAt              time.Time                  `json:"expire_at"`
+}
+
+type NativeConnectionCredential struct {
+	Hostname     string `json:"hostname"`
+	Port         string `json:"port"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	DatabaseName string `json:"database_name"`
+}
+
+// CreateConnectionCredentials requests short-lived native credentials to access a connection.
+// The connection could be referenced by its name or id.
+func (c *Client) CreateConnectionCredentials(connection string, accessDurationSec int64) (*ConnectionCredentials, error) {
+	apiURL := fmt.Sprintf("%s/connections/%s/credentials", c.apiURL, connection)
+	body, err := json.Marshal(map[string]any{"access_duration_seconds": accessDurationSec})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal connection credentials request, reason=%v", err)
+	}
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request, reason=%v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Api-Key", c.token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection credentials, reason=%v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK {
+		var resource ConnectionCredentials
+		if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+			return nil, fmt.Errorf("failed decoding connection credentials resource, reason=%v", err)
+		}
+		return &resource, nil
+	}
+	return nil, validateErr(resp)
+}
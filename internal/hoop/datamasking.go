@@ -17,6 +17,10 @@ type DataMaskingRule struct {
 	ConnectionIDs        []string                    `json:"connection_ids"`
 	SupportedEntityTypes []SupportedEntityTypesEntry `json:"supported_entity_types"`
 	CustomEntityTypes    []CustomEntityTypesEntry    `json:"custom_entity_types"`
+
+	// ETag is the version of the rule returned by the gateway,
+	// it's sent in the If-Match header when updating or deleting it.
+	ETag string `json:"-"`
 }
 
 type SupportedEntityTypesEntry struct {
@@ -49,11 +53,56 @@ func (c *Client) GetDatamaskingRule(resourceID string) (*DataMaskingRule, error)
 		if err != nil {
 			return nil, fmt.Errorf("failed decoding data masking rule resource, reason=%v", err)
 		}
+		resource.ETag = resp.Header.Get("ETag")
 		return &resource, nil
 	}
 	return nil, validateErr(resp)
 }
 
+func (c *Client) ListDatamaskingRules() ([]DataMaskingRule, error) {
+	apiURL := fmt.Sprintf("%s/datamasking-rules", c.apiURL)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request, reason=%v", err)
+	}
+	req.Header.Set("Api-Key", c.token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		var resources []DataMaskingRule
+		if err := json.NewDecoder(resp.Body).Decode(&resources); err != nil {
+			return nil, fmt.Errorf("failed decoding data masking rules resource, reason=%v", err)
+		}
+		return resources, nil
+	}
+	return nil, validateErr(resp)
+}
+
+// GetDatamaskingRuleByName returns the data masking rule with the unique name, as listed by the gateway.
+func (c *Client) GetDatamaskingRuleByName(name string) (*DataMaskingRule, error) {
+	rules, err := c.ListDatamaskingRules()
+	if err != nil {
+		return nil, err
+	}
+	var found *DataMaskingRule
+	for _, rule := range rules {
+		if rule.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple data masking rules with name %q, use the id of the rule instead", name)
+		}
+		found = &rule
+	}
+	if found == nil {
+		return nil, fmt.Errorf("data masking rule with name %q not found", name)
+	}
+	return found, nil
+}
+
 func (c *Client) CreateDatamaskingRule(rule DataMaskingRule) (*DataMaskingRule, error) {
 	apiURL := fmt.Sprintf("%s/datamasking-rules", c.apiURL)
 	body, err := json.Marshal(rule)
@@ -77,6 +126,7 @@ func (c *Client) CreateDatamaskingRule(rule DataMaskingRule) (*DataMaskingRule,
 		if err != nil {
 			return nil, fmt.Errorf("failed decoding data masking rule resource, reason=%v", err)
 		}
+		resource.ETag = resp.Header.Get("ETag")
 		return &resource, nil
 	}
 	return nil, validateErr(resp)
@@ -94,6 +144,7 @@ func (c *Client) UpdateDatamaskingRule(rule DataMaskingRule) (*DataMaskingRule,
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Api-Key", c.token)
+	c.setIfMatch(req, rule.ETag)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update data masking rule, reason=%v", err)
@@ -105,18 +156,20 @@ func (c *Client) UpdateDatamaskingRule(rule DataMaskingRule) (*DataMaskingRule,
 		if err != nil {
 			return nil, fmt.Errorf("failed decoding data masking rule resource, reason=%v", err)
 		}
+		resource.ETag = resp.Header.Get("ETag")
 		return &resource, nil
 	}
 	return nil, validateErr(resp)
 }
 
-func (c *Client) DeleteDatamaskingRule(resourceID string) error {
+func (c *Client) DeleteDatamaskingRule(resourceID, etag string) error {
 	apiURL := fmt.Sprintf("%s/datamasking-rules/%s", c.apiURL, resourceID)
 	req, err := http.NewRequest("DELETE", apiURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request, reason=%v", err)
 	}
 	req.Header.Set("Api-Key", c.token)
+	c.setIfMatch(req, etag)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete data masking rule, reason=%v", err)
@@ -173,3 +226,83 @@ func (c *Client) DeleteDatamaskingRule(resourceID string) error {
 // 	conn.Secrets = secrets
 // 	return json.Marshal(conn)
 // }
+
+// DataMaskingPreviewRequest is the sample text analyzed with the entities of a data masking rule,
+// the entities are configured inline when the rule id is empty.
+type DataMaskingPreviewRequest struct {
+	Text                 string                      `json:"text"`
+	RuleID               string                      `json:"rule_id,omitempty"`
+	ScoreThreshold       *float64                    `json:"score_threshold,omitempty"`
+	SupportedEntityTypes []SupportedEntityTypesEntry `json:"supported_entity_types,omitempty"`
+	CustomEntityTypes    []CustomEntityTypesEntry    `json:"custom_entity_types,omitempty"`
+}
+
+type DataMaskingPreview struct {
+	RedactedText string               `json:"redacted_text"`
+	Findings     []DataMaskingFinding `json:"findings"`
+}
+
+// DataMaskingFinding is an entity detected in the sample text,
+// start and end are the character offsets of the entity in the text.
+type DataMaskingFinding struct {
+	EntityType string  `json:"entity_type"`
+	Start      int64   `json:"start"`
+	End        int64   `json:"end"`
+	Score      float64 `json:"score"`
+}
+
+// PreviewDatamasking analyzes and redacts the sample text without persisting anything in the gateway.
+func (c *Client) PreviewDatamasking(preview DataMaskingPreviewRequest) (*DataMaskingPreview, error) {
+	apiURL := fmt.Sprintf("%s/datamasking-rules/preview", c.apiURL)
+	body, err := json.Marshal(preview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data masking preview, reason=%v", err)
+	}
+	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request, reason=%v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Api-Key", c.token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview data masking, reason=%v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		var resource DataMaskingPreview
+		if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+			return nil, fmt.Errorf("failed decoding data masking preview resource, reason=%v", err)
+		}
+		return &resource, nil
+	}
+	return nil, validateErr(resp)
+}
+
+// DataMaskingEntityTypes is the catalog of entity types supported by the DLP provider of the gateway.
+type DataMaskingEntityTypes struct {
+	Provider    string   `json:"provider"`
+	EntityTypes []string `json:"entity_types"`
+}
+
+func (c *Client) ListDatamaskingEntityTypes() (*DataMaskingEntityTypes, error) {
+	apiURL := fmt.Sprintf("%s/datamasking-rules/entity-types", c.apiURL)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request, reason=%v", err)
+	}
+	req.Header.Set("Api-Key", c.token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		var resource DataMaskingEntityTypes
+		if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+			return nil, fmt.Errorf("failed decoding data masking entity types resource, reason=%v", err)
+		}
+		return &resource, nil
+	}
+	return nil, validateErr(resp)
+}
@@ -112,6 +112,54 @@ func (c *Client) DeletePluginConfig(pluginName string) error {
 	return err
 }
 
+const (
+	// SlackPluginName is the name of the plugin sending the reviews of the sessions to Slack
+	SlackPluginName = "slack"
+	// SlackBotTokenKey is the configuration key of the token of the Slack bot user
+	SlackBotTokenKey = "SLACK_BOT_TOKEN"
+	// SlackAppTokenKey is the configuration key of the app-level token of the Slack app
+	SlackAppTokenKey = "SLACK_APP_TOKEN"
+)
+
+// SlackPluginConfig is the typed configuration of the Slack plugin.
+type SlackPluginConfig struct {
+	ID       string
+	BotToken string
+	AppToken string
+}
+
+// GetSlackPluginConfig returns the configuration of the Slack plugin, it's nil when the plugin is not configured.
+func (c *Client) GetSlackPluginConfig() (*SlackPluginConfig, error) {
+	pluginConfig, err := c.GetPluginConfig(SlackPluginName)
+	if err != nil || pluginConfig == nil {
+		return nil, err
+	}
+	return &SlackPluginConfig{
+		ID:       pluginConfig.ID,
+		BotToken: pluginConfig.EnvVars[SlackBotTokenKey],
+		AppToken: pluginConfig.EnvVars[SlackAppTokenKey],
+	}, nil
+}
+
+// UpsertSlackPluginConfig replaces the configuration of the Slack plugin with the tokens of the Slack app.
+func (c *Client) UpsertSlackPluginConfig(config SlackPluginConfig) (*SlackPluginConfig, error) {
+	pluginConfig, err := c.upsertPluginConfig(SlackPluginName, map[string]string{
+		SlackBotTokenKey: config.BotToken,
+		SlackAppTokenKey: config.AppToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pluginConfig == nil {
+		return nil, fmt.Errorf("plugin %s returned an empty configuration", SlackPluginName)
+	}
+	return &SlackPluginConfig{
+		ID:       pluginConfig.ID,
+		BotToken: pluginConfig.EnvVars[SlackBotTokenKey],
+		AppToken: pluginConfig.EnvVars[SlackAppTokenKey],
+	}, nil
+}
+
 func (c *Client) upsertPluginConfig(pluginName string, config map[string]string) (*PluginConfig, error) {
 	apiURL := fmt.Sprintf("%s/plugins/%s/config", c.apiURL, pluginName)
 	newConfig := map[string]string{}
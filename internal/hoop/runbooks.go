@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/google/uuid"
 )
@@ -24,9 +25,35 @@ type RunbookRepo struct {
 	SSHKey        string `json:"ssh_key"`
 	SSHKeyPass    string `json:"ssh_keypass"`
 	SSHKnownHosts string `json:"ssh_known_hosts"`
+	// GitRef is the branch, tag or commit served by the gateway, the default branch is used when it's empty
+	GitRef string `json:"git_ref,omitempty"`
+	// PathPrefix restricts the runbooks to the ones under this path of the repository
+	PathPrefix string `json:"path_prefix,omitempty"`
+	// ResolvedCommit is the commit the gateway is serving, it's set by the gateway
+	ResolvedCommit string `json:"resolved_commit,omitempty"`
 }
 
-func (c *Client) GetRunbookConfigByURL(gitURL string) (*RunbookRepo, error) {
+// RunbookRepoID returns the identifier of the runbook repository configuration, it's derived from the git url.
+func RunbookRepoID(gitURL string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(gitURL)).String()
+}
+
+// GetRunbookRepo returns the runbook repository configuration matching the key,
+// it could be the identifier, the git url or the normalized name of the repository.
+func (c *Client) GetRunbookRepo(key string) (*RunbookRepo, error) {
+	repositories, err := c.ListRunbookRepos()
+	if err != nil {
+		return nil, err
+	}
+	for _, repo := range repositories {
+		if repo.GitURL == key || repo.Repository == key || RunbookRepoID(repo.GitURL) == key {
+			return &repo, nil
+		}
+	}
+	return nil, fmt.Errorf("runbook repository %q not found", key)
+}
+
+func (c *Client) ListRunbookRepos() ([]RunbookRepo, error) {
 	apiURL := fmt.Sprintf("%s/runbooks/configurations", c.apiURL)
 	req, err := http.NewRequest("GET", apiURL, nil)
 	if err != nil {
@@ -44,12 +71,7 @@ func (c *Client) GetRunbookConfigByURL(gitURL string) (*RunbookRepo, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed decoding runbooks configuration resource, reason=%v", err)
 		}
-		for _, repo := range resource.Repositories {
-			if repo.GitURL == gitURL {
-				return &repo, nil
-			}
-		}
-		return nil, fmt.Errorf("git repository %q not found", gitURL)
+		return resource.Repositories, nil
 	}
 	return nil, validateErr(resp)
 }
@@ -59,13 +81,11 @@ func (c *Client) CreateRunbookRepo(repo RunbookRepo) (*RunbookRepo, error) {
 }
 
 func (c *Client) UpdateRunbookRepoByID(repo RunbookRepo) (*RunbookRepo, error) {
-	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte(repo.GitURL)).String()
-	return c.doRunbookRequestWithBody(id, repo)
+	return c.doRunbookRequestWithBody(RunbookRepoID(repo.GitURL), repo)
 }
 
 func (c *Client) DeleteRunbookRepoByID(gitURL string) error {
-	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte(gitURL)).String()
-	apiURL := fmt.Sprintf("%s/runbooks/configurations/%s", c.apiURL, id)
+	apiURL := fmt.Sprintf("%s/runbooks/configurations/%s", c.apiURL, RunbookRepoID(gitURL))
 	req, err := http.NewRequest("DELETE", apiURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DELETE request, reason=%v", err)
@@ -117,3 +137,56 @@ func (c *Client) doRunbookRequestWithBody(id string, repo RunbookRepo) (*Runbook
 	}
 	return nil, validateErr(resp)
 }
+
+type RunbookList struct {
+	Repositories []RunbookRepositoryList `json:"repositories"`
+}
+
+type RunbookRepositoryList struct {
+	Repository string    `json:"repository"`
+	Commit     string    `json:"commit"`
+	Items      []Runbook `json:"items"`
+}
+
+type Runbook struct {
+	// Name is the relative git path of the runbook file
+	Name     string                      `json:"name"`
+	Metadata map[string]RunbookParameter `json:"metadata"`
+}
+
+type RunbookParameter struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Default     string   `json:"default"`
+	Options     []string `json:"options"`
+}
+
+// ListRunbooks returns the runbooks of a configured repository.
+// The repository is the normalized name of the repository, e.g.: github.com/hoophq/runbooks
+func (c *Client) ListRunbooks(repository string) (*RunbookRepositoryList, error) {
+	apiURL := fmt.Sprintf("%s/runbooks?repository=%s", c.apiURL, url.QueryEscape(repository))
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request, reason=%v", err)
+	}
+	req.Header.Set("Api-Key", c.token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		var resource RunbookList
+		if err := json.NewDecoder(resp.Body).Decode(&resource); err != nil {
+			return nil, fmt.Errorf("failed decoding runbooks resource, reason=%v", err)
+		}
+		for _, repo := range resource.Repositories {
+			if repo.Repository == repository {
+				return &repo, nil
+			}
+		}
+		return nil, fmt.Errorf("repository %q not found, make sure it has a runbook configuration", repository)
+	}
+	return nil, validateErr(resp)
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path"
+	"strings"
 )
 
 type RunbookRule struct {
@@ -16,9 +18,33 @@ type RunbookRule struct {
 	Runbooks    []RunbookRuleItem `json:"runbooks"`
 }
 
+const (
+	// RunbookMatchExact matches the runbook with the exact relative git path
+	RunbookMatchExact = "exact"
+	// RunbookMatchGlob matches the runbooks with a glob pattern, the wildcard * doesn't match the path separator
+	RunbookMatchGlob = "glob"
+	// RunbookMatchPrefix matches the runbooks under a directory or starting with a prefix
+	RunbookMatchPrefix = "prefix"
+)
+
 type RunbookRuleItem struct {
 	Name       string `json:"name"`
 	Repository string `json:"repository"`
+	// Match is how the name matches the runbooks, it defaults to exact when it's empty
+	Match string `json:"match,omitempty"`
+}
+
+// Matches reports whether the item matches the relative git path of a runbook.
+func (i RunbookRuleItem) Matches(runbookName string) (bool, error) {
+	switch i.Match {
+	case "", RunbookMatchExact:
+		return i.Name == runbookName, nil
+	case RunbookMatchGlob:
+		return path.Match(i.Name, runbookName)
+	case RunbookMatchPrefix:
+		return strings.HasPrefix(runbookName, i.Name), nil
+	}
+	return false, fmt.Errorf("unknown match type %q", i.Match)
 }
 
 func (c *Client) GetRunbookRuleByID(id string) (*RunbookRule, error) {
@@ -44,6 +70,52 @@ func (c *Client) GetRunbookRuleByID(id string) (*RunbookRule, error) {
 	return nil, validateErr(resp)
 }
 
+func (c *Client) ListRunbookRules() ([]RunbookRule, error) {
+	apiURL := fmt.Sprintf("%s/runbooks/rules", c.apiURL)
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request, reason=%v", err)
+	}
+	req.Header.Set("Api-Key", c.token)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		var resources []RunbookRule
+		err := json.NewDecoder(resp.Body).Decode(&resources)
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding runbook rules resource, reason=%v", err)
+		}
+		return resources, nil
+	}
+	return nil, validateErr(resp)
+}
+
+// GetRunbookRuleByName returns the runbook rule with the name,
+// the name must identify a single rule of the gateway.
+func (c *Client) GetRunbookRuleByName(name string) (*RunbookRule, error) {
+	rules, err := c.ListRunbookRules()
+	if err != nil {
+		return nil, err
+	}
+	var found *RunbookRule
+	for _, rule := range rules {
+		if rule.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple runbook rules with name %q, use the id of the rule instead", name)
+		}
+		found = &rule
+	}
+	if found == nil {
+		return nil, fmt.Errorf("runbook rule with name %q not found", name)
+	}
+	return found, nil
+}
+
 func (c *Client) CreateRunbookRule(rule RunbookRule) (*RunbookRule, error) {
 	return c.doRunbookRuleRequestWithBody("", rule)
 }
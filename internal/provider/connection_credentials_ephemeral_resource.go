@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// defaultCredentialsAccessDuration is the access duration requested when
+// the attribute access_duration_seconds is not set.
+const defaultCredentialsAccessDuration = int64(1800)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &connectionCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &connectionCredentialsEphemeralResource{}
+)
+
+// NewConnectionCredentialsEphemeralResource is a helper function to simplify the provider implementation.
+func NewConnectionCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &connectionCredentialsEphemeralResource{}
+}
+
+// connectionCredentialsEphemeralResourceModel maps the ephemeral resource schema data.
+type connectionCredentialsEphemeralResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Connection            types.String `tfsdk:"connection"`
+	AccessDurationSeconds types.Int64  `tfsdk:"access_duration_seconds"`
+	Host                  types.String `tfsdk:"host"`
+	Port                  types.String `tfsdk:"port"`
+	User                  types.String `tfsdk:"user"`
+	Password              types.String `tfsdk:"password"`
+	DatabaseName          types.String `tfsdk:"database_name"`
+	ExpireAt              types.String `tfsdk:"expire_at"`
+}
+
+// connectionCredentialsEphemeralResource is the ephemeral resource implementation.
+type connectionCredentialsEphemeralResource struct {
+	client *hoop.Client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *connectionCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection_credentials"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *connectionCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Requests short-lived native credentials to access a connection. The credentials are never persisted in the plan or state, " +
+			"making it suitable to configure other providers during a single run. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the issued credentials.",
+				Computed:    true,
+			},
+			"connection": schema.StringAttribute{
+				Description: "The name or the ID of the connection to request credentials for.",
+				Required:    true,
+				Validators:  NonEmptyStringValidator,
+			},
+			"access_duration_seconds": schema.Int64Attribute{
+				Description: fmt.Sprintf("How long the credentials are valid, in seconds. Defaults to `%v`.", defaultCredentialsAccessDuration),
+				Optional:    true,
+				Validators:  PositiveInt64Validator,
+			},
+			"host": schema.StringAttribute{
+				Description: "The host to connect to.",
+				Computed:    true,
+			},
+			"port": schema.StringAttribute{
+				Description: "The port to connect to.",
+				Computed:    true,
+			},
+			"user": schema.StringAttribute{
+				Description: "The temporary user to authenticate with.",
+				Computed:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "The temporary password or token to authenticate with.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"database_name": schema.StringAttribute{
+				Description: "The database name, only available for database type connections.",
+				Computed:    true,
+			},
+			"expire_at": schema.StringAttribute{
+				Description: "The date and time (RFC3339) when the credentials expire.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Open requests the credentials to the gateway.
+func (r *connectionCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data connectionCredentialsEphemeralResourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessDuration := defaultCredentialsAccessDuration
+	if !data.AccessDurationSeconds.IsNull() {
+		accessDuration = data.AccessDurationSeconds.ValueInt64()
+	}
+
+	creds, err := r.client.CreateConnectionCredentials(data.Connection.ValueString(), accessDuration)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Connection Credentials",
+			fmt.Sprintf("Failed to create credentials for connection %q: %v", data.Connection.ValueString(), err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(creds.ID)
+	data.AccessDurationSeconds = types.Int64Value(accessDuration)
+	data.Host = types.StringValue(creds.ConnectionCredentials.Hostname)
+	data.Port = types.StringValue(creds.ConnectionCredentials.Port)
+	data.User = types.StringValue(creds.ConnectionCredentials.Username)
+	data.Password = types.StringValue(creds.ConnectionCredentials.Password)
+	data.DatabaseName = types.StringValue(creds.ConnectionCredentials.DatabaseName)
+	data.ExpireAt = types.StringValue(creds.ExpireAt.Format(time.RFC3339))
+
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *connectionCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hoop.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = client
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func createFakeConnectionCredentialsTestServer() clientFunc {
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		// POST /api/connections/{name}/credentials endpoint
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/credentials"):
+			var body struct {
+				AccessDurationSec int64 `json:"access_duration_seconds"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return httpTestErr(http.StatusBadRequest, `test: unable to decode request body: %v`, err), nil
+			}
+			parts := strings.Split(req.URL.Path, "/")
+			createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			return httpTestOk(http.StatusCreated, hoop.ConnectionCredentials{
+				ID:                "0b5e3e9f-6bb5-4a53-9a37-58cba17ac0b1",
+				ConnectionName:    parts[len(parts)-2],
+				ConnectionType:    "database",
+				ConnectionSubType: "postgres",
+				ConnectionCredentials: hoop.NativeConnectionCredential{
+					Hostname:     "127.0.0.1",
+					Port:         "5432",
+					Username:     "tmp-user",
+					Password:     "tmp-password",
+					DatabaseName: "dellstore",
+				},
+				CreatedAt: createdAt,
+				ExpireAt:  createdAt.Add(time.Duration(body.AccessDurationSec) * time.Second),
+			}), nil
+		}
+		return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+	})
+}
+
+func TestConnectionCredentialsEphemeralResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionCredentialsTestServer())()),
+			"echo": echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+ephemeral "hoop_connection_credentials" "pg" {
+  connection              = "pgdemo"
+  access_duration_seconds = 600
+}
+
+provider "echo" {
+  data = ephemeral.hoop_connection_credentials.pg
+}
+
+resource "echo" "creds" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.creds", "data.connection", "pgdemo"),
+					resource.TestCheckResourceAttr("echo.creds", "data.host", "127.0.0.1"),
+					resource.TestCheckResourceAttr("echo.creds", "data.port", "5432"),
+					resource.TestCheckResourceAttr("echo.creds", "data.user", "tmp-user"),
+					resource.TestCheckResourceAttr("echo.creds", "data.password", "tmp-password"),
+					resource.TestCheckResourceAttr("echo.creds", "data.database_name", "dellstore"),
+					resource.TestCheckResourceAttr("echo.creds", "data.expire_at", "2025-01-01T00:10:00Z"),
+				),
+			},
+		},
+	})
+}
@@ -4,23 +4,37 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &connectionResource{}
-	_ resource.ResourceWithConfigure = &connectionResource{}
+	_ resource.Resource               = &connectionResource{}
+	_ resource.ResourceWithConfigure  = &connectionResource{}
+	_ resource.ResourceWithModifyPlan = &connectionResource{}
 )
 
 // NewconnectionResource is a helper function to simplify the provider implementation.
@@ -38,20 +52,69 @@ type connectionResourceModel struct {
 	Subtype             types.String `tfsdk:"subtype"`
 	Command             types.List   `tfsdk:"command"`
 	Secrets             types.Map    `tfsdk:"secrets"`
-	Reviewers           types.List   `tfsdk:"reviewers"`
+	SecretsWO           types.Map    `tfsdk:"secrets_wo"`
+	SecretsSHA256       types.Map    `tfsdk:"secrets_sha256"`
+	SecretFiles         types.Map    `tfsdk:"secret_files"`
+	Reviewers           types.Set    `tfsdk:"reviewers"`
+	Review              types.Object `tfsdk:"review"`
+	RedactEnabled       types.Bool   `tfsdk:"redact_enabled"`
 	RedactTypes         types.List   `tfsdk:"redact_types"`
 	Tags                types.Map    `tfsdk:"tags"`
+	TagsAll             types.Map    `tfsdk:"tags_all"`
 	AccessModeRunbooks  types.String `tfsdk:"access_mode_runbooks"`
 	AccessModeExec      types.String `tfsdk:"access_mode_exec"`
 	AccessModeConnect   types.String `tfsdk:"access_mode_connect"`
 	AccessSchema        types.String `tfsdk:"access_schema"`
-	GuardRailRules      types.List   `tfsdk:"guardrail_rules"`
+	GuardRailRules      types.Set    `tfsdk:"guardrail_rules"`
 	JiraIssueTemplateID types.String `tfsdk:"jira_issue_template_id"`
+	DeletionProtection  types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// connectionReviewModel maps the review attribute of the connection resource.
+type connectionReviewModel struct {
+	ApproverGroups        types.Set    `tfsdk:"approver_groups"`
+	MinApprovals          types.Int64  `tfsdk:"min_approvals"`
+	ExecReviewRequired    types.Bool   `tfsdk:"exec_review_required"`
+	ConnectReviewRequired types.Bool   `tfsdk:"connect_review_required"`
+	JitMaxDurationSeconds types.Int64  `tfsdk:"jit_max_duration_seconds"`
+	TimeWindow            types.Object `tfsdk:"time_window"`
+}
+
+// connectionReviewTimeWindowModel maps the review time window attribute of the connection resource.
+type connectionReviewTimeWindowModel struct {
+	StartTime types.String `tfsdk:"start_time"`
+	EndTime   types.String `tfsdk:"end_time"`
+	Timezone  types.String `tfsdk:"timezone"`
+}
+
+var connectionReviewTimeWindowAttrTypes = map[string]attr.Type{
+	"start_time": types.StringType,
+	"end_time":   types.StringType,
+	"timezone":   types.StringType,
+}
+
+// connectionSecretFileModel maps the entries of the secret_files attribute.
+type connectionSecretFileModel struct {
+	ContentBase64 types.String `tfsdk:"content_base64"`
+}
+
+var connectionSecretFileAttrTypes = map[string]attr.Type{
+	"content_base64": types.StringType,
+}
+
+var connectionReviewAttrTypes = map[string]attr.Type{
+	"approver_groups":          types.SetType{ElemType: types.StringType},
+	"min_approvals":            types.Int64Type,
+	"exec_review_required":     types.BoolType,
+	"connect_review_required":  types.BoolType,
+	"jit_max_duration_seconds": types.Int64Type,
+	"time_window":              types.ObjectType{AttrTypes: connectionReviewTimeWindowAttrTypes},
 }
 
 // connectionResource is the data source implementation.
 type connectionResource struct {
 	client *hoop.Client
+	tags   tagsConfig
 }
 
 // Metadata returns the resource type name.
@@ -63,6 +126,7 @@ func (r *connectionResource) Metadata(_ context.Context, req resource.MetadataRe
 func (r *connectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manage a connection resource in Hoop Platform.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the connection resource.",
@@ -72,11 +136,8 @@ func (r *connectionResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"name": schema.StringAttribute{
-				Description: "The name of the connection resource.",
+				Description: "The name of the connection resource. Changing it renames the connection in place.",
 				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"agent_id": schema.StringAttribute{
 				Description: "The ID of the agent associated with the connection.",
@@ -105,11 +166,119 @@ func (r *connectionResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Validators:  NonEmptyMapValidator,
 				Sensitive:   true,
 			},
-			"reviewers": schema.ListAttribute{
-				Description: "A list of approver groups that are allowed to approve a session.",
+			"secrets_wo": schema.MapAttribute{
+				Description: "A write-only map of secrets to be used by the connection, it accepts the same keys as `secrets`. " +
+					"The values are never persisted in the state, only their SHA-256 fingerprints are kept in `secrets_sha256` to detect drift. " +
+					"Requires Terraform 1.11 or later and conflicts with `secrets`.",
 				Optional:    true,
+				WriteOnly:   true,
+				Sensitive:   true,
 				ElementType: types.StringType,
-				Validators:  NonEmptyListValidator,
+				Validators: append([]validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("secrets")),
+				}, NonEmptyMapValidator...),
+			},
+			"secret_files": schema.MapNestedAttribute{
+				Description: "A map of files to be used by the connection keyed by the secret name, e.g.: `KUBECONFIG`. " +
+					"Each entry is sent to the gateway as the secret `filesystem:KEY_NAME` without being decoded, " +
+					"making it suitable for binary files such as keystores and client certificates.",
+				Optional: true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content_base64": schema.StringAttribute{
+							Description: "The base64 encoded content of the file, e.g.: `filebase64(\"client.p12\")`.",
+							Required:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+			"secrets_sha256": schema.MapAttribute{
+				Description: "The SHA-256 fingerprint (hex encoded) of each secret value keyed by the secret name.",
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"reviewers": schema.SetAttribute{
+				Description: "A set of approver groups that are allowed to approve a session.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  NonEmptySetValidator,
+			},
+			"review": schema.SingleNestedAttribute{
+				Description: "The approval policy of the connection sessions. Conflicts with `reviewers`.",
+				Optional:    true,
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("reviewers")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"approver_groups": schema.SetAttribute{
+						Description: "A set of approver groups that are allowed to approve a session.",
+						Required:    true,
+						ElementType: types.StringType,
+						Validators:  NonEmptySetValidator,
+					},
+					"min_approvals": schema.Int64Attribute{
+						Description: "The minimum number of approvals required before a session is released. Defaults to `1`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(1),
+						Validators:  PositiveInt64Validator,
+					},
+					"exec_review_required": schema.BoolAttribute{
+						Description: "Requires a review to execute one off commands in the connection. Defaults to `true`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"connect_review_required": schema.BoolAttribute{
+						Description: "Requires a review (just-in-time access) to connect natively to the connection. Defaults to `true`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+					"jit_max_duration_seconds": schema.Int64Attribute{
+						Description: "The maximum duration, in seconds, that a just-in-time access could be requested for.",
+						Optional:    true,
+						Validators:  PositiveInt64Validator,
+					},
+					"time_window": schema.SingleNestedAttribute{
+						Description: "The daily time window in which an approved session is allowed to be used.",
+						Optional:    true,
+						Attributes: map[string]schema.Attribute{
+							"start_time": schema.StringAttribute{
+								Description: "The start of the time window in the format `HH:MM`.",
+								Required:    true,
+								Validators:  TimeOfDayValidator,
+							},
+							"end_time": schema.StringAttribute{
+								Description: "The end of the time window in the format `HH:MM`.",
+								Required:    true,
+								Validators:  TimeOfDayValidator,
+							},
+							"timezone": schema.StringAttribute{
+								Description: "The IANA time zone of the time window, e.g.: `America/Sao_Paulo`. Defaults to `UTC`.",
+								Optional:    true,
+								Computed:    true,
+								Default:     stringdefault.StaticString("UTC"),
+								Validators:  NonEmptyStringValidator,
+							},
+						},
+					},
+				},
+			},
+			"redact_enabled": schema.BoolAttribute{
+				Description: "Enables or disables the data masking of the connection. Defaults to `true` when creating the connection, " +
+					"when omitted the value managed by the gateway is preserved on updates.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"redact_types": schema.ListAttribute{
 				Description:        "A list of redact types, these values are dependent of which DLP provider is being used.",
@@ -119,11 +288,16 @@ func (r *connectionResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				DeprecationMessage: "Redact types are deprecated and will be removed in a future version. Use the `hoop_datamasking_rules` resource instead.",
 			},
 			"tags": schema.MapAttribute{
-				Description: "A map of tags to be associated with the connection.",
+				Description: "A map of tags to be associated with the connection. They are merged with the `default_tags` of the provider.",
 				Optional:    true,
 				ElementType: types.StringType,
 				Validators:  NonEmptyMapValidator,
 			},
+			"tags_all": schema.MapAttribute{
+				Description: "A map of all tags of the connection, including the `default_tags` of the provider. The tags matching the `ignore_tags` of the provider are not included.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"access_mode_runbooks": schema.StringAttribute{
 				Description: "Enables or disables access to runbooks for the connection. Accept values are `enabled` or `disabled`.",
 				Required:    true,
@@ -145,17 +319,18 @@ func (r *connectionResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Validators:  AccessModeValidator,
 			},
 
-			"guardrail_rules": schema.ListAttribute{
-				Description: "A list of guardrail rule ids to be applied to the connection.",
+			"guardrail_rules": schema.SetAttribute{
+				Description: "A set of guardrail rule ids to be applied to the connection.",
 				Optional:    true,
 				ElementType: types.StringType,
-				Validators:  NonEmptyListValidator,
+				Validators:  NonEmptySetValidator,
 			},
 			"jira_issue_template_id": schema.StringAttribute{
 				Description: "The ID of the Jira issue template to be used for the connection.",
 				Optional:    true,
 				Validators:  NonEmptyStringValidator,
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
@@ -171,7 +346,12 @@ func (r *connectionResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 	tflog.Info(ctx, "running read for connection resource")
 
-	connection, err := r.client.GetConnection(currentState.Name.ValueString())
+	// the id is set with the name or the ID of the connection when importing it
+	nameOrID := currentState.ID.ValueString()
+	if currentState.ID.IsNull() {
+		nameOrID = currentState.Name.ValueString()
+	}
+	connection, err := r.client.GetConnection(nameOrID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Connection",
@@ -180,7 +360,7 @@ func (r *connectionResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	newState, diags := toConnectionResourceModel(ctx, currentState, connection)
+	newState, diags := toConnectionResourceModel(ctx, currentState, connection, r.tags)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection Model",
@@ -188,6 +368,11 @@ func (r *connectionResource) Read(ctx context.Context, req resource.ReadRequest,
 		)
 		return
 	}
+	newState.DeletionProtection = deletionProtectionValue(currentState.DeletionProtection)
+	resp.Diagnostics.Append(setPrivateETag(ctx, resp.Private, connection.ETag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Set refreshed state
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -205,6 +390,13 @@ func (r *connectionResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	// write-only attributes are only available in the configuration
+	diags = req.Config.GetAttribute(ctx, path.Root("secrets_wo"), &plan.SecretsWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	requestConnection, diags := toConnectionHoopAPI(ctx, plan)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
@@ -214,6 +406,8 @@ func (r *connectionResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	requestConnection.ConnectionTags = r.tags.merge(requestConnection.ConnectionTags)
+
 	connection, err := r.client.CreateConnection(requestConnection)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -225,6 +419,15 @@ func (r *connectionResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(connection.ID)
+	plan.RedactEnabled = types.BoolValue(requestConnection.RedactEnabled)
+	plan.SecretsSHA256, diags = fingerprintSecrets(ctx, requestConnection.Secrets)
+	resp.Diagnostics.Append(diags...)
+	plan.TagsAll, diags = tagsMapValue(ctx, r.tags.withoutIgnored(requestConnection.ConnectionTags))
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setPrivateETag(ctx, resp.Private, connection.ETag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &plan)
@@ -244,6 +447,13 @@ func (r *connectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	// write-only attributes are only available in the configuration
+	diags = req.Config.GetAttribute(ctx, path.Root("secrets_wo"), &plan.SecretsWO)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	reqConn, diags := toConnectionHoopAPI(ctx, plan)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
@@ -253,7 +463,37 @@ func (r *connectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	newConn, err := r.client.UpdateConnection(reqConn)
+	reqConn.ETag, diags = getPrivateETag(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqConn.ConnectionTags = r.tags.merge(reqConn.ConnectionTags)
+	var mergeIgnoredTags func(current, conn *hoop.Connection)
+	if r.tags.hasIgnoredTags() {
+		// keep the tags managed outside of terraform
+		mergeIgnoredTags = func(current, conn *hoop.Connection) {
+			conn.ConnectionTags = r.tags.withIgnored(conn.ConnectionTags, current.ConnectionTags)
+		}
+	}
+
+	newConn, err := r.client.UpdateConnection(reqConn, mergeIgnoredTags)
+	if hoop.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Updating Connection",
+			fmt.Sprintf("The connection %q no longer exists, it was deleted outside of Terraform. "+
+				"Refresh the state to create it again.", reqConn.Name),
+		)
+		return
+	}
+	if hoop.IsPreconditionFailed(err) {
+		resp.Diagnostics.AddError(
+			"Error Updating Connection",
+			preconditionFailedDetail(fmt.Sprintf("connection %q", reqConn.Name)),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Connection",
@@ -262,7 +502,7 @@ func (r *connectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	newState, diags := toConnectionResourceModel(ctx, plan, newConn)
+	newState, diags := toConnectionResourceModel(ctx, plan, newConn, r.tags)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection Model",
@@ -270,6 +510,10 @@ func (r *connectionResource) Update(ctx context.Context, req resource.UpdateRequ
 		)
 		return
 	}
+	resp.Diagnostics.Append(setPrivateETag(ctx, resp.Private, newConn.ETag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, newState)
 	resp.Diagnostics.Append(diags...)
@@ -287,7 +531,28 @@ func (r *connectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.DeleteConnection(state.Name.ValueString())
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Error Deleting Connection",
+			deletionProtectionErrorDetail(fmt.Sprintf("connection %q", state.Name.ValueString())),
+		)
+		return
+	}
+
+	etag, diags := getPrivateETag(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteConnection(state.ID.ValueString(), etag)
+	if hoop.IsPreconditionFailed(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Connection",
+			preconditionFailedDetail(fmt.Sprintf("connection %q", state.Name.ValueString())),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Connection",
@@ -297,8 +562,71 @@ func (r *connectionResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// ModifyPlan computes the fingerprints of the configured secrets. A fingerprint
+// that differs from the one read from the API means the secret has drifted.
+func (r *connectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var secrets types.Map
+	diags := req.Config.GetAttribute(ctx, path.Root("secrets"), &secrets)
+	resp.Diagnostics.Append(diags...)
+	if secrets.IsNull() {
+		diags = req.Config.GetAttribute(ctx, path.Root("secrets_wo"), &secrets)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secretsKnown := !secrets.IsUnknown()
+	for _, val := range secrets.Elements() {
+		secretsKnown = secretsKnown && !val.IsUnknown()
+	}
+
+	secretsSHA256 := types.MapUnknown(types.StringType)
+	if secretsKnown {
+		var secretValues map[string]string
+		diags = secrets.ElementsAs(ctx, &secretValues, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		secretsSHA256, diags = fingerprintSecrets(ctx, secretValues)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secrets_sha256"), secretsSHA256)...)
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll := types.MapUnknown(types.StringType)
+	if !tags.IsUnknown() {
+		var tagValues map[string]string
+		resp.Diagnostics.Append(tags.ElementsAs(ctx, &tagValues, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tagsAll, diags = tagsMapValue(ctx, r.tags.withoutIgnored(r.tags.merge(tagValues)))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// ImportState imports a connection by its name or ID, the id attribute
+// is refreshed with the ID of the connection when reading it.
 func (r *connectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 // Configure adds the provider configured client to the data source.
@@ -309,19 +637,20 @@ func (r *connectionResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.tags = data.tags
 }
 
-func toConnectionResourceModel(ctx context.Context, state connectionResourceModel, obj *hoop.Connection) (newState *connectionResourceModel, diags diag.Diagnostics) {
+func toConnectionResourceModel(ctx context.Context, state connectionResourceModel, obj *hoop.Connection, tags tagsConfig) (newState *connectionResourceModel, diags diag.Diagnostics) {
 	// required attributes
 	state.ID = types.StringValue(obj.ID)
 	state.Name = types.StringValue(obj.Name)
@@ -331,6 +660,7 @@ func toConnectionResourceModel(ctx context.Context, state connectionResourceMode
 	state.AccessModeExec = types.StringValue(obj.AccessModeExec)
 	state.AccessModeConnect = types.StringValue(obj.AccessModeConnect)
 	state.AccessSchema = types.StringValue(obj.AccessSchema)
+	state.RedactEnabled = types.BoolValue(obj.RedactEnabled)
 
 	state.Command, diags = types.ListValueFrom(ctx, types.StringType, obj.Command)
 	if diags.HasError() {
@@ -342,24 +672,29 @@ func toConnectionResourceModel(ctx context.Context, state connectionResourceMode
 		state.Command = types.ListNull(types.StringType)
 	}
 
-	state.Reviewers, diags = types.ListValueFrom(ctx, types.StringType, obj.Reviewers)
+	state.Reviewers, diags = types.SetValueFrom(ctx, types.StringType, obj.Reviewers)
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	// coerce the optional field to a ListNull if it is empty
+	// coerce the optional field to a SetNull if it is empty
 	if len(state.Reviewers.Elements()) == 0 {
-		state.Reviewers = types.ListNull(types.StringType)
+		state.Reviewers = types.SetNull(types.StringType)
 	}
 
-	state.GuardRailRules, diags = types.ListValueFrom(ctx, types.StringType, obj.GuardRailRules)
+	state.Review, diags = fromApiConnectionReview(ctx, obj.Review)
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	// coerce the optional field to a ListNull if it is empty
+	state.GuardRailRules, diags = types.SetValueFrom(ctx, types.StringType, obj.GuardRailRules)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// coerce the optional field to a SetNull if it is empty
 	if len(state.GuardRailRules.Elements()) == 0 {
-		state.GuardRailRules = types.ListNull(types.StringType)
+		state.GuardRailRules = types.SetNull(types.StringType)
 	}
 
 	state.RedactTypes, diags = types.ListValueFrom(ctx, types.StringType, obj.RedactTypes)
@@ -372,24 +707,45 @@ func toConnectionResourceModel(ctx context.Context, state connectionResourceMode
 		state.RedactTypes = types.ListNull(types.StringType)
 	}
 
-	state.Secrets, diags = types.MapValueFrom(ctx, types.StringType, obj.Secrets)
+	secrets, secretFiles := splitConnectionSecretFiles(state.SecretFiles, obj)
+	state.SecretFiles, diags = fromApiConnectionSecretFiles(secretFiles)
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	// coerce the optional field to a ListNull if it is empty
-	if len(state.Secrets.Elements()) == 0 {
-		state.Secrets = types.MapNull(types.StringType)
+	// the secrets managed by the write-only attribute are tracked only
+	// by their fingerprints, the values are never persisted in the state
+	secretsHashOnly := state.Secrets.IsNull() && !state.SecretsSHA256.IsNull()
+	state.SecretsSHA256, diags = fingerprintSecrets(ctx, secrets)
+	if diags.HasError() {
+		return nil, diags
 	}
 
-	state.Tags, diags = types.MapValueFrom(ctx, types.StringType, obj.ConnectionTags)
+	if !secretsHashOnly {
+		state.Secrets, diags = types.MapValueFrom(ctx, types.StringType, secrets)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// coerce the optional field to a ListNull if it is empty
+		if len(state.Secrets.Elements()) == 0 {
+			state.Secrets = types.MapNull(types.StringType)
+		}
+	}
+
+	// the tags inherited from the provider and the ignored ones are not part of the tags attribute
+	var resourceTags map[string]string
+	diags = state.Tags.ElementsAs(ctx, &resourceTags, true)
 	if diags.HasError() {
 		return nil, diags
 	}
-
-	// coerce the optional field to a MapNull if it is empty
-	if len(state.Tags.Elements()) == 0 {
-		state.Tags = types.MapNull(types.StringType)
+	state.Tags, diags = tagsMapValue(ctx, tags.resourceTags(obj.ConnectionTags, resourceTags))
+	if diags.HasError() {
+		return nil, diags
+	}
+	state.TagsAll, diags = tagsMapValue(ctx, tags.withoutIgnored(obj.ConnectionTags))
+	if diags.HasError() {
+		return nil, diags
 	}
 
 	state.Subtype = types.StringValue(obj.SubType)
@@ -414,11 +770,15 @@ func toConnectionHoopAPI(ctx context.Context, obj connectionResourceModel) (conn
 	if diags.HasError() {
 		return
 	}
-	Reviewers, diags := convertListToStringSlice(ctx, obj.Reviewers)
+	Reviewers, diags := convertSetToStringSlice(ctx, obj.Reviewers)
 	if diags.HasError() {
 		return
 	}
-	guardRailRules, diags := convertListToStringSlice(ctx, obj.GuardRailRules)
+	guardRailRules, diags := convertSetToStringSlice(ctx, obj.GuardRailRules)
+	if diags.HasError() {
+		return
+	}
+	review, diags := toApiConnectionReview(ctx, obj.Review)
 	if diags.HasError() {
 		return
 	}
@@ -429,21 +789,49 @@ func toConnectionHoopAPI(ctx context.Context, obj connectionResourceModel) (conn
 		return conn, diags
 	}
 
+	// data masking is enabled by default when the attribute is not set
+	redactEnabled := true
+	if !obj.RedactEnabled.IsNull() && !obj.RedactEnabled.IsUnknown() {
+		redactEnabled = obj.RedactEnabled.ValueBool()
+	}
+
+	secretsAttr := obj.Secrets
+	if secretsAttr.IsNull() {
+		secretsAttr = obj.SecretsWO
+	}
 	var secrets map[string]string
-	diags = obj.Secrets.ElementsAs(ctx, &secrets, false)
+	diags = secretsAttr.ElementsAs(ctx, &secrets, false)
+	if diags.HasError() {
+		return conn, diags
+	}
+
+	secretFiles, diags := toApiConnectionSecretFiles(ctx, obj.SecretFiles)
 	if diags.HasError() {
 		return conn, diags
 	}
+	for name := range secretFiles {
+		if _, ok := secrets[hoop.SecretFilePrefix+name]; ok {
+			diags.AddAttributeError(
+				path.Root("secret_files").AtMapKey(name),
+				"Conflicting Secret File",
+				fmt.Sprintf("The secret %q is set in both secrets and secret_files, remove one of them.", hoop.SecretFilePrefix+name),
+			)
+			return conn, diags
+		}
+	}
 
 	return hoop.Connection{
+		ID:                  obj.ID.ValueString(),
 		Name:                obj.Name.ValueString(),
 		Command:             command,
 		Type:                obj.Type.ValueString(),
 		SubType:             obj.Subtype.ValueString(),
 		Secrets:             secrets,
+		SecretFiles:         secretFiles,
 		AgentId:             obj.AgentID.ValueString(),
 		Reviewers:           Reviewers,
-		RedactEnabled:       true,
+		Review:              review,
+		RedactEnabled:       redactEnabled,
 		RedactTypes:         redactTypes,
 		ConnectionTags:      connectionTags,
 		AccessModeRunbooks:  obj.AccessModeRunbooks.ValueString(),
@@ -455,6 +843,144 @@ func toConnectionHoopAPI(ctx context.Context, obj connectionResourceModel) (conn
 	}, nil
 }
 
+// toApiConnectionSecretFiles decodes the content of the secret files.
+func toApiConnectionSecretFiles(ctx context.Context, obj types.Map) (map[string][]byte, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+	var files map[string]connectionSecretFileModel
+	diags := obj.ElementsAs(ctx, &files, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	secretFiles := make(map[string][]byte, len(files))
+	for name, file := range files {
+		content, err := base64.StdEncoding.DecodeString(file.ContentBase64.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("secret_files").AtMapKey(name).AtName("content_base64"),
+				"Invalid Secret File Content",
+				fmt.Sprintf("The content of the secret file %q must be base64 encoded: %v", name, err),
+			)
+			return nil, diags
+		}
+		secretFiles[name] = content
+	}
+	return secretFiles, nil
+}
+
+// splitConnectionSecretFiles separates the filesystem secrets managed by the secret_files
+// attribute from the secrets of the connection. The gateway returns the text files as
+// regular secrets, only the binary ones are decoded as secret files by the client.
+func splitConnectionSecretFiles(secretFilesAttr types.Map, obj *hoop.Connection) (map[string]string, map[string][]byte) {
+	secrets := make(map[string]string, len(obj.Secrets))
+	for key, val := range obj.Secrets {
+		secrets[key] = val
+	}
+	secretFiles := make(map[string][]byte, len(obj.SecretFiles))
+	for name, content := range obj.SecretFiles {
+		secretFiles[name] = content
+	}
+	for name := range secretFilesAttr.Elements() {
+		key := hoop.SecretFilePrefix + name
+		if val, ok := secrets[key]; ok {
+			secretFiles[name] = []byte(val)
+			delete(secrets, key)
+		}
+	}
+	return secrets, secretFiles
+}
+
+// fromApiConnectionSecretFiles converts the secret files to the secret_files attribute,
+// it returns a null map when there are no files.
+func fromApiConnectionSecretFiles(secretFiles map[string][]byte) (types.Map, diag.Diagnostics) {
+	fileType := types.ObjectType{AttrTypes: connectionSecretFileAttrTypes}
+	if len(secretFiles) == 0 {
+		return types.MapNull(fileType), nil
+	}
+	var diags diag.Diagnostics
+	files := make(map[string]attr.Value, len(secretFiles))
+	for name, content := range secretFiles {
+		file, d := types.ObjectValue(connectionSecretFileAttrTypes, map[string]attr.Value{
+			"content_base64": types.StringValue(base64.StdEncoding.EncodeToString(content)),
+		})
+		diags.Append(d...)
+		files[name] = file
+	}
+	if diags.HasError() {
+		return types.MapNull(fileType), diags
+	}
+	return types.MapValue(fileType, files)
+}
+
+func toApiConnectionReview(ctx context.Context, obj types.Object) (*hoop.ConnectionReview, diag.Diagnostics) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, nil
+	}
+	var review connectionReviewModel
+	diags := obj.As(ctx, &review, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	approverGroups, diags := convertSetToStringSlice(ctx, review.ApproverGroups)
+	if diags.HasError() {
+		return nil, diags
+	}
+	apiReview := &hoop.ConnectionReview{
+		ApproverGroups:        approverGroups,
+		MinApprovals:          review.MinApprovals.ValueInt64(),
+		ExecReviewRequired:    review.ExecReviewRequired.ValueBool(),
+		ConnectReviewRequired: review.ConnectReviewRequired.ValueBool(),
+		JitMaxDurationSec:     review.JitMaxDurationSeconds.ValueInt64(),
+	}
+	if !review.TimeWindow.IsNull() && !review.TimeWindow.IsUnknown() {
+		var timeWindow connectionReviewTimeWindowModel
+		diags = review.TimeWindow.As(ctx, &timeWindow, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			return nil, diags
+		}
+		apiReview.TimeWindow = &hoop.ConnectionReviewWindow{
+			StartTime: timeWindow.StartTime.ValueString(),
+			EndTime:   timeWindow.EndTime.ValueString(),
+			Timezone:  timeWindow.Timezone.ValueString(),
+		}
+	}
+	return apiReview, nil
+}
+
+func fromApiConnectionReview(ctx context.Context, review *hoop.ConnectionReview) (types.Object, diag.Diagnostics) {
+	if review == nil {
+		return types.ObjectNull(connectionReviewAttrTypes), nil
+	}
+	approverGroups, diags := types.SetValueFrom(ctx, types.StringType, review.ApproverGroups)
+	if diags.HasError() {
+		return types.ObjectNull(connectionReviewAttrTypes), diags
+	}
+	model := connectionReviewModel{
+		ApproverGroups:        approverGroups,
+		MinApprovals:          types.Int64Value(review.MinApprovals),
+		ExecReviewRequired:    types.BoolValue(review.ExecReviewRequired),
+		ConnectReviewRequired: types.BoolValue(review.ConnectReviewRequired),
+		JitMaxDurationSeconds: types.Int64Null(),
+		TimeWindow:            types.ObjectNull(connectionReviewTimeWindowAttrTypes),
+	}
+	// coerce the optional field to null if it is empty
+	if review.JitMaxDurationSec > 0 {
+		model.JitMaxDurationSeconds = types.Int64Value(review.JitMaxDurationSec)
+	}
+	if review.TimeWindow != nil {
+		model.TimeWindow, diags = types.ObjectValueFrom(ctx, connectionReviewTimeWindowAttrTypes, connectionReviewTimeWindowModel{
+			StartTime: types.StringValue(review.TimeWindow.StartTime),
+			EndTime:   types.StringValue(review.TimeWindow.EndTime),
+			Timezone:  types.StringValue(review.TimeWindow.Timezone),
+		})
+		if diags.HasError() {
+			return types.ObjectNull(connectionReviewAttrTypes), diags
+		}
+	}
+	return types.ObjectValueFrom(ctx, connectionReviewAttrTypes, model)
+}
+
 func convertListToStringSlice(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
 	if list.IsNull() || list.IsUnknown() {
 		return nil, nil
@@ -468,3 +994,57 @@ func convertListToStringSlice(ctx context.Context, list types.List) ([]string, d
 
 	return result, nil
 }
+
+func convertSetToStringSlice(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+
+	var result []string
+	diags := set.ElementsAs(ctx, &result, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return result, nil
+}
+
+// convertListToSet converts a list of strings into a set, duplicated
+// elements are dropped. It's used when migrating list attributes to sets.
+func convertListToSet(ctx context.Context, list types.List) (types.Set, diag.Diagnostics) {
+	if list.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+	if list.IsUnknown() {
+		return types.SetUnknown(types.StringType), nil
+	}
+
+	items, diags := convertListToStringSlice(ctx, list)
+	if diags.HasError() {
+		return types.SetNull(types.StringType), diags
+	}
+	seen := make(map[string]struct{}, len(items))
+	uniqueItems := make([]string, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		uniqueItems = append(uniqueItems, item)
+	}
+	return types.SetValueFrom(ctx, types.StringType, uniqueItems)
+}
+
+// fingerprintSecrets returns the hex encoded SHA-256 digest of each secret value.
+// It returns a null map when there are no secrets.
+func fingerprintSecrets(ctx context.Context, secrets map[string]string) (types.Map, diag.Diagnostics) {
+	if len(secrets) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+	fingerprints := make(map[string]string, len(secrets))
+	for key, val := range secrets {
+		sum := sha256.Sum256([]byte(val))
+		fingerprints[key] = hex.EncodeToString(sum[:])
+	}
+	return types.MapValueFrom(ctx, types.StringType, fingerprints)
+}
@@ -3,20 +3,42 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
 )
 
 func createFakeConnectionTestServer() clientFunc {
-	store := map[string]*hoop.Connection{}
+	return createFakeConnectionTestServerWithStore(map[string]*hoop.Connection{})
+}
+
+// createFakeConnectionTestServerWithStore serves the connections of the store, keyed by name.
+// Connections are addressed by name or ID in the path, as the gateway does.
+func createFakeConnectionTestServerWithStore(store map[string]*hoop.Connection) clientFunc {
+	lookup := func(nameOrID string) (*hoop.Connection, bool) {
+		for _, conn := range store {
+			if conn.Name == nameOrID || conn.ID == nameOrID {
+				return conn, true
+			}
+		}
+		return nil, false
+	}
 	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		nameOrID := strings.TrimPrefix(req.URL.Path, "/api/connections/")
 		switch req.Method {
 		case http.MethodPost:
 			var reqConn hoop.Connection
@@ -30,27 +52,31 @@ func createFakeConnectionTestServer() clientFunc {
 			store[reqConn.Name] = &reqConn
 			return httpTestOk(http.StatusCreated, reqConn), nil
 		case http.MethodDelete:
-			if _, ok := store["bash"]; !ok {
-				return httpTestErr(http.StatusNotFound, `connection with name bash not found`), nil
+			conn, ok := lookup(nameOrID)
+			if !ok {
+				return httpTestErr(http.StatusNotFound, `connection %q not found`, nameOrID), nil
 			}
-			delete(store, "bash")
+			delete(store, conn.Name)
 			return httpTestErr(http.StatusNoContent, ""), nil
 		case http.MethodGet:
-			if conn, ok := store["bash"]; ok {
+			if conn, ok := lookup(nameOrID); ok {
 				return httpTestOk(http.StatusOK, conn), nil
 			}
-
-			return httpTestErr(http.StatusNotFound, `connection with name bash not found`), nil
+			return httpTestErr(http.StatusNotFound, `connection %q not found`, nameOrID), nil
 		case http.MethodPut:
 			var reqConn hoop.Connection
 			if err := json.NewDecoder(req.Body).Decode(&reqConn); err != nil {
 				return httpTestErr(http.StatusInternalServerError, `unable to decode  request, reason: %v`, err), nil
 			}
-			existentConn, ok := store[reqConn.Name]
+			existentConn, ok := lookup(nameOrID)
 			if !ok {
-				return httpTestErr(http.StatusNotFound, `connection with name %q not found`, reqConn.Name), nil
+				return httpTestErr(http.StatusNotFound, `connection %q not found`, nameOrID), nil
+			}
+			if _, ok := store[reqConn.Name]; ok && reqConn.Name != existentConn.Name {
+				return httpTestErr(http.StatusConflict, `connection with name %q already exists`, reqConn.Name), nil
 			}
 			reqConn.ID = existentConn.ID // keep the same ID
+			delete(store, existentConn.Name)
 			store[reqConn.Name] = &reqConn
 			return httpTestOk(http.StatusOK, reqConn), nil
 		}
@@ -113,7 +139,7 @@ resource "hoop_connection" "bash" {
 					resource.TestCheckResourceAttr("hoop_connection.bash", "command.0", "/bin/bash"),
 					resource.TestCheckResourceAttr("hoop_connection.bash", "secrets.envvar:MYENV", "value"),
 					resource.TestCheckResourceAttr("hoop_connection.bash", "secrets.filesystem:MYFILE", "file-content"),
-					resource.TestCheckResourceAttr("hoop_connection.bash", "reviewers.0", "admin"),
+					resource.TestCheckTypeSetElemAttr("hoop_connection.bash", "reviewers.*", "admin"),
 					resource.TestCheckResourceAttr("hoop_connection.bash", "redact_types.0", "EMAIL_ADDRESS"),
 					resource.TestCheckResourceAttr("hoop_connection.bash", "redact_types.1", "PHONE_NUMBER"),
 					resource.TestCheckResourceAttr("hoop_connection.bash", "access_mode_runbooks", "enabled"),
@@ -233,3 +259,707 @@ resource "hoop_connection" "bash" {
 		},
 	})
 }
+
+func TestConnectionResourceSecretsWriteOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  secrets_wo = {
+    "envvar:MYENV" = "value"
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec = "enabled"
+  access_mode_connect = "enabled"
+  access_schema = "enabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("hoop_connection.bash", "secrets_wo"),
+					resource.TestCheckNoResourceAttr("hoop_connection.bash", "secrets.%"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "secrets_sha256.envvar:MYENV",
+						"cd42404d52ad55ccfa9aca4adc828aa5800ad9d385a0671fbcbf724118320619"),
+				),
+			},
+			// Rotating the secret value
+			{
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  secrets_wo = {
+    "envvar:MYENV" = "file-content"
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec = "enabled"
+  access_mode_connect = "enabled"
+  access_schema = "enabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("hoop_connection.bash", "secrets.%"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "secrets_sha256.envvar:MYENV",
+						"2239ce4df9ee8db012834642ec801b55ba2c92b28bdd11f4d73d9c55d39f3b0a"),
+				),
+			},
+		},
+	})
+}
+
+func TestConnectionResourceReview(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  review = {
+    approver_groups = ["dba", "sre"]
+    min_approvals   = 2
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec = "enabled"
+  access_mode_connect = "enabled"
+  access_schema = "enabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("hoop_connection.bash", "review.approver_groups.*", "dba"),
+					resource.TestCheckTypeSetElemAttr("hoop_connection.bash", "review.approver_groups.*", "sre"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.min_approvals", "2"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.exec_review_required", "true"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.connect_review_required", "true"),
+					resource.TestCheckNoResourceAttr("hoop_connection.bash", "review.jit_max_duration_seconds"),
+					resource.TestCheckNoResourceAttr("hoop_connection.bash", "review.time_window.start_time"),
+				),
+			},
+			// Update Testing
+			{
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  review = {
+    approver_groups          = ["dba"]
+    exec_review_required     = false
+    jit_max_duration_seconds = 3600
+    time_window = {
+      start_time = "09:00"
+      end_time   = "18:00"
+    }
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec = "enabled"
+  access_mode_connect = "enabled"
+  access_schema = "enabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.approver_groups.#", "1"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.min_approvals", "1"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.exec_review_required", "false"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.connect_review_required", "true"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.jit_max_duration_seconds", "3600"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.time_window.start_time", "09:00"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.time_window.end_time", "18:00"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "review.time_window.timezone", "UTC"),
+				),
+			},
+			{
+				ResourceName:                         "hoop_connection.bash",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateId:                        "bash",
+			},
+		},
+	})
+}
+
+func TestConnectionResourcePreserveUnmanagedFields(t *testing.T) {
+	store := map[string]*hoop.Connection{}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServerWithStore(store))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "disabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "redact_enabled", "true"),
+				),
+			},
+			{
+				// simulate changes made outside of terraform
+				PreConfig: func() {
+					store["bash"].RedactEnabled = false
+					store["bash"].Extra = map[string]json.RawMessage{"access_max_duration": json.RawMessage(`3600`)}
+				},
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "enabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "access_schema", "enabled"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "redact_enabled", "false"),
+					func(_ *terraform.State) error {
+						if got := string(store["bash"].Extra["access_max_duration"]); got != "3600" {
+							return fmt.Errorf("expected unmanaged field access_max_duration to be preserved, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// createFakeETagConnectionTestServer versions the connections of the fake server with the ETag header
+// and rejects writes with a stale If-Match header. The received If-Match headers are recorded in ifMatch.
+func createFakeETagConnectionTestServer(ifMatch *[]string, conflict *bool) clientFunc {
+	server := createFakeConnectionTestServer()
+	version := 0
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPut || req.Method == http.MethodDelete {
+			*ifMatch = append(*ifMatch, req.Header.Get("If-Match"))
+			currentETag := fmt.Sprintf(`"%d"`, version)
+			if *conflict || (req.Header.Get("If-Match") != "" && req.Header.Get("If-Match") != currentETag) {
+				return httpTestErr(http.StatusPreconditionFailed, `connection has changed, etag=%v`, currentETag), nil
+			}
+		}
+		resp, err := server(req)
+		if err != nil || resp.StatusCode >= http.StatusBadRequest {
+			return resp, err
+		}
+		if req.Method != http.MethodGet {
+			version++
+		}
+		resp.Header.Set("ETag", fmt.Sprintf(`"%d"`, version))
+		return resp, nil
+	})
+}
+
+func TestConnectionResourceOptimisticLocking(t *testing.T) {
+	var ifMatch []string
+	conflict := false
+	resourceConfig := func(accessSchema string) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = %q
+}
+`, accessSchema)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeETagConnectionTestServer(&ifMatch, &conflict))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: resourceConfig("disabled"),
+			},
+			{
+				Config: resourceConfig("enabled"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "access_schema", "enabled"),
+					func(_ *terraform.State) error {
+						if len(ifMatch) != 1 || ifMatch[0] != `"1"` {
+							return fmt.Errorf(`expected the update to send If-Match "1", got %q`, ifMatch)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// simulate a change made after the plan was created
+				PreConfig:   func() { conflict = true },
+				Config:      resourceConfig("disabled"),
+				ExpectError: regexp.MustCompile(`re-run plan`),
+			},
+			{
+				PreConfig: func() { conflict = false },
+				Config:    resourceConfig("disabled"),
+				Check:     resource.TestCheckResourceAttr("hoop_connection.bash", "access_schema", "disabled"),
+			},
+		},
+	})
+}
+
+func TestConnectionResourceOptimisticLockingDisabled(t *testing.T) {
+	var ifMatch []string
+	conflict := false
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeETagConnectionTestServer(&ifMatch, &conflict))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key            = "orgid|hash"
+  api_url            = "http://localhost:8009/api"
+  optimistic_locking = false
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "disabled"
+}
+`,
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key            = "orgid|hash"
+  api_url            = "http://localhost:8009/api"
+  optimistic_locking = false
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "enabled"
+}
+`,
+				Check: func(_ *terraform.State) error {
+					if len(ifMatch) != 1 || ifMatch[0] != "" {
+						return fmt.Errorf("expected the update to not send If-Match, got %q", ifMatch)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestConnectionResourceRename(t *testing.T) {
+	store := map[string]*hoop.Connection{}
+	var connectionID string
+	resourceConfig := func(name string) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = %q
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "disabled"
+}
+`, name)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServerWithStore(store))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: resourceConfig("bash"),
+				Check: func(_ *terraform.State) error {
+					connectionID = store["bash"].ID
+					return nil
+				},
+			},
+			{
+				Config: resourceConfig("bash-console"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hoop_connection.bash", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "name", "bash-console"),
+					func(s *terraform.State) error {
+						return resource.TestCheckResourceAttr("hoop_connection.bash", "id", connectionID)(s)
+					},
+				),
+			},
+			// import by ID
+			{
+				ResourceName:      "hoop_connection.bash",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(_ *terraform.State) (string, error) {
+					return connectionID, nil
+				},
+			},
+		},
+	})
+}
+
+func TestConnectionResourceDeletionProtection(t *testing.T) {
+	resourceConfig := func(deletionProtection bool) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "disabled"
+
+  deletion_protection = %v
+}
+`, deletionProtection)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: resourceConfig(true),
+				Check:  resource.TestCheckResourceAttr("hoop_connection.bash", "deletion_protection", "true"),
+			},
+			{
+				Config:      resourceConfig(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`deletion_protection enabled`),
+			},
+			{
+				Config: resourceConfig(false),
+				Check:  resource.TestCheckResourceAttr("hoop_connection.bash", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestConnectionResourceDefaultTags(t *testing.T) {
+	store := map[string]*hoop.Connection{}
+	resourceConfig := func(accessSchema string) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+
+  default_tags = {
+    team       = "platform"
+    managed_by = "terraform"
+  }
+
+  ignore_tags = {
+    key_prefixes = ["external:"]
+  }
+}
+
+resource "hoop_connection" "bash" {
+  name     = "bash"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = %q
+
+  tags = {
+    team        = "database"
+    environment = "production"
+  }
+}
+`, accessSchema)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServerWithStore(store))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: resourceConfig("disabled"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags.%", "2"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags.team", "database"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags_all.%", "3"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags_all.team", "database"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags_all.environment", "production"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags_all.managed_by", "terraform"),
+				),
+			},
+			{
+				// simulate tags added by other tooling
+				PreConfig: func() {
+					store["bash"].ConnectionTags["external:owner"] = "security"
+				},
+				Config: resourceConfig("disabled"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: resourceConfig("enabled"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.bash", "access_schema", "enabled"),
+					resource.TestCheckResourceAttr("hoop_connection.bash", "tags_all.%", "3"),
+					func(_ *terraform.State) error {
+						if got := store["bash"].ConnectionTags["external:owner"]; got != "security" {
+							return fmt.Errorf("expected the ignored tag to be preserved, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestConnectionResourceSecretFiles(t *testing.T) {
+	store := map[string]*hoop.Connection{}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeConnectionTestServerWithStore(store))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "k8s" {
+  name     = "k8s"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  secrets = {
+    "envvar:NAMESPACE" = "default"
+  }
+
+  secret_files = {
+    KEYSTORE = {
+      content_base64 = "AAEC/w=="
+    }
+    KUBECONFIG = {
+      content_base64 = "a2luZDogQ29uZmlnCg=="
+    }
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "disabled"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_connection.k8s", "secrets.%", "1"),
+					resource.TestCheckResourceAttr("hoop_connection.k8s", "secrets_sha256.%", "1"),
+					resource.TestCheckResourceAttr("hoop_connection.k8s", "secret_files.KEYSTORE.content_base64", "AAEC/w=="),
+					resource.TestCheckResourceAttr("hoop_connection.k8s", "secret_files.KUBECONFIG.content_base64", "a2luZDogQ29uZmlnCg=="),
+					func(_ *terraform.State) error {
+						// the content is sent to the gateway without being encoded twice
+						secrets := store["k8s"].Secrets
+						if got := secrets["filesystem:KEYSTORE"]; got != "AAEC/w==" {
+							return fmt.Errorf("expected the keystore to be sent as is, got %q", got)
+						}
+						if got := secrets["filesystem:KUBECONFIG"]; got != "a2luZDogQ29uZmlnCg==" {
+							return fmt.Errorf("expected the kubeconfig to be sent as is, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// the files are read back without drift
+				Config: `
+provider "hoop" {
+  api_key = "orgid|hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_connection" "k8s" {
+  name     = "k8s"
+  type     = "custom"
+  agent_id = "75122bce-f957-49eb-a812-2ab60977cd9f"
+
+  secrets = {
+    "envvar:NAMESPACE" = "default"
+  }
+
+  secret_files = {
+    KEYSTORE = {
+      content_base64 = "AAEC/w=="
+    }
+    KUBECONFIG = {
+      content_base64 = "a2luZDogQ29uZmlnCg=="
+    }
+  }
+
+  access_mode_runbooks = "enabled"
+  access_mode_exec     = "enabled"
+  access_mode_connect  = "enabled"
+  access_schema        = "disabled"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestConnectionSecretFilesRoundTrip(t *testing.T) {
+	store := map[string]*hoop.Connection{}
+	client := hoop.NewClient("http://localhost:8009/api", "orgid|hash", createFakeConnectionTestServerWithStore(store))
+	keystore := []byte{0x00, 0x01, 0x02, 0xff}
+	_, err := client.CreateConnection(hoop.Connection{
+		Name:        "k8s",
+		Secrets:     map[string]string{"envvar:NAMESPACE": "default"},
+		SecretFiles: map[string][]byte{"KEYSTORE": keystore, "KUBECONFIG": []byte("kind: Config\n")},
+	})
+	if err != nil {
+		t.Fatalf("failed creating connection: %v", err)
+	}
+
+	conn, err := client.GetConnection("k8s")
+	if err != nil {
+		t.Fatalf("failed reading connection: %v", err)
+	}
+	if got := conn.SecretFiles["KEYSTORE"]; !bytes.Equal(got, keystore) {
+		t.Errorf("expected the binary file to round-trip as a secret file, got %v", got)
+	}
+
+	secretFilesAttr, diags := fromApiConnectionSecretFiles(map[string][]byte{"KUBECONFIG": nil})
+	if diags.HasError() {
+		t.Fatalf("failed converting secret files: %v", diags)
+	}
+	secrets, secretFiles := splitConnectionSecretFiles(secretFilesAttr, conn)
+	if want := map[string]string{"envvar:NAMESPACE": "default"}; !reflect.DeepEqual(secrets, want) {
+		t.Errorf("expected secrets %v, got %v", want, secrets)
+	}
+	want := map[string][]byte{"KEYSTORE": keystore, "KUBECONFIG": []byte("kind: Config\n")}
+	if !reflect.DeepEqual(secretFiles, want) {
+		t.Errorf("expected secret files %v, got %v", want, secretFiles)
+	}
+}
+
+func TestConnectionUpdateNotFound(t *testing.T) {
+	client := hoop.NewClient("http://localhost:8009/api", "orgid|hash", createFakeConnectionTestServerWithStore(map[string]*hoop.Connection{}))
+	_, err := client.UpdateConnection(hoop.Connection{ID: "0c7f3a1e-5d0b-4a8e-9d1c-2b3e4f5a6b7c", Name: "deleted"}, nil)
+	if !hoop.IsNotFound(err) {
+		t.Errorf("expected a not found error updating a deleted connection, got %v", err)
+	}
+}
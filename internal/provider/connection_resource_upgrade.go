@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &connectionResource{}
+
+// connectionResourceModelV0 maps the schema version 0 of the connection resource,
+// where reviewers and guardrail_rules were lists.
+type connectionResourceModelV0 struct {
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	AgentID             types.String `tfsdk:"agent_id"`
+	Type                types.String `tfsdk:"type"`
+	Subtype             types.String `tfsdk:"subtype"`
+	Command             types.List   `tfsdk:"command"`
+	Secrets             types.Map    `tfsdk:"secrets"`
+	Reviewers           types.List   `tfsdk:"reviewers"`
+	RedactTypes         types.List   `tfsdk:"redact_types"`
+	Tags                types.Map    `tfsdk:"tags"`
+	AccessModeRunbooks  types.String `tfsdk:"access_mode_runbooks"`
+	AccessModeExec      types.String `tfsdk:"access_mode_exec"`
+	AccessModeConnect   types.String `tfsdk:"access_mode_connect"`
+	AccessSchema        types.String `tfsdk:"access_schema"`
+	GuardRailRules      types.List   `tfsdk:"guardrail_rules"`
+	JiraIssueTemplateID types.String `tfsdk:"jira_issue_template_id"`
+}
+
+// connectionSchemaV0 is the schema version 0 of the connection resource.
+// It must not be changed, it's only used to decode prior states.
+func connectionSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                     schema.StringAttribute{Computed: true},
+			"name":                   schema.StringAttribute{Required: true},
+			"agent_id":               schema.StringAttribute{Required: true},
+			"type":                   schema.StringAttribute{Required: true},
+			"subtype":                schema.StringAttribute{Optional: true},
+			"command":                schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"secrets":                schema.MapAttribute{Optional: true, Sensitive: true, ElementType: types.StringType},
+			"reviewers":              schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"redact_types":           schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"tags":                   schema.MapAttribute{Optional: true, ElementType: types.StringType},
+			"access_mode_runbooks":   schema.StringAttribute{Required: true},
+			"access_mode_exec":       schema.StringAttribute{Required: true},
+			"access_mode_connect":    schema.StringAttribute{Required: true},
+			"access_schema":          schema.StringAttribute{Required: true},
+			"guardrail_rules":        schema.ListAttribute{Optional: true, ElementType: types.StringType},
+			"jira_issue_template_id": schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+// UpgradeState upgrades prior states of the connection resource to the current schema version.
+func (r *connectionResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: connectionSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState connectionResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState, diags := upgradeConnectionStateV0(ctx, priorState)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+	}
+}
+
+// upgradeConnectionStateV0 converts the list attributes of the schema version 0 to sets
+// and sets the attributes added after the schema version 0 to their empty values.
+func upgradeConnectionStateV0(ctx context.Context, prior connectionResourceModelV0) (state connectionResourceModel, diags diag.Diagnostics) {
+	state = connectionResourceModel{
+		ID:                  prior.ID,
+		Name:                prior.Name,
+		AgentID:             prior.AgentID,
+		Type:                prior.Type,
+		Subtype:             prior.Subtype,
+		Command:             prior.Command,
+		Secrets:             prior.Secrets,
+		SecretsWO:           types.MapNull(types.StringType),
+		SecretsSHA256:       types.MapNull(types.StringType),
+		SecretFiles:         types.MapNull(types.ObjectType{AttrTypes: connectionSecretFileAttrTypes}),
+		RedactEnabled:       types.BoolNull(),
+		Review:              types.ObjectNull(connectionReviewAttrTypes),
+		RedactTypes:         prior.RedactTypes,
+		Tags:                prior.Tags,
+		TagsAll:             types.MapNull(types.StringType),
+		AccessModeRunbooks:  prior.AccessModeRunbooks,
+		AccessModeExec:      prior.AccessModeExec,
+		AccessModeConnect:   prior.AccessModeConnect,
+		AccessSchema:        prior.AccessSchema,
+		JiraIssueTemplateID: prior.JiraIssueTemplateID,
+		DeletionProtection:  types.BoolValue(false),
+	}
+
+	state.Reviewers, diags = convertListToSet(ctx, prior.Reviewers)
+	if diags.HasError() {
+		return
+	}
+	state.GuardRailRules, diags = convertListToSet(ctx, prior.GuardRailRules)
+	return
+}
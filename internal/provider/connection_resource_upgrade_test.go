@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+func TestConnectionResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	var state connectionResourceModel
+	upgradeTestState(t, NewConnectionResource, 0, `{
+  "id": "6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1",
+  "name": "bash-console",
+  "agent_id": "75122bce-f957-49eb-a812-2ab60977cd9f",
+  "type": "custom",
+  "subtype": null,
+  "command": ["/bin/bash"],
+  "secrets": {"envvar:KEY": "value"},
+  "reviewers": ["sre", "admin", "sre"],
+  "redact_types": null,
+  "tags": null,
+  "access_mode_runbooks": "enabled",
+  "access_mode_exec": "enabled",
+  "access_mode_connect": "disabled",
+  "access_schema": "disabled",
+  "guardrail_rules": ["rule1"],
+  "jira_issue_template_id": null
+}`, &state)
+
+	if state.Name.ValueString() != "bash-console" || state.AccessModeConnect.ValueString() != "disabled" {
+		t.Errorf("expected attributes to be preserved, got name=%v, access_mode_connect=%v", state.Name, state.AccessModeConnect)
+	}
+	if len(state.Command.Elements()) != 1 || len(state.Secrets.Elements()) != 1 {
+		t.Errorf("expected command and secrets to be preserved, got %v, %v", state.Command, state.Secrets)
+	}
+
+	reviewers, diags := convertSetToStringSlice(ctx, state.Reviewers)
+	if diags.HasError() {
+		t.Fatalf("failed converting reviewers: %v", diags)
+	}
+	sort.Strings(reviewers)
+	if len(reviewers) != 2 || reviewers[0] != "admin" || reviewers[1] != "sre" {
+		t.Errorf("expected reviewers to be a set of [admin sre], got %v", reviewers)
+	}
+
+	guardRailRules, diags := convertSetToStringSlice(ctx, state.GuardRailRules)
+	if diags.HasError() {
+		t.Fatalf("failed converting guardrail rules: %v", diags)
+	}
+	if len(guardRailRules) != 1 || guardRailRules[0] != "rule1" {
+		t.Errorf("expected guardrail rules to be a set of [rule1], got %v", guardRailRules)
+	}
+
+	if !state.Review.IsNull() || !state.SecretsWO.IsNull() || !state.SecretsSHA256.IsNull() || !state.SecretFiles.IsNull() {
+		t.Errorf("expected attributes missing in the prior state to be null, got review=%v, secrets_wo=%v, secrets_sha256=%v, secret_files=%v",
+			state.Review, state.SecretsWO, state.SecretsSHA256, state.SecretFiles)
+	}
+	if state.DeletionProtection.ValueBool() {
+		t.Errorf("expected deletion_protection to default to false, got %v", state.DeletionProtection)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &datamaskingEntityTypesDataSource{}
+	_ datasource.DataSourceWithConfigure = &datamaskingEntityTypesDataSource{}
+)
+
+// NewDatamaskingEntityTypesDataSource is a helper function to simplify the provider implementation.
+func NewDatamaskingEntityTypesDataSource() datasource.DataSource {
+	return &datamaskingEntityTypesDataSource{}
+}
+
+// datamaskingEntityTypesDataSourceModel maps the data source schema data.
+type datamaskingEntityTypesDataSourceModel struct {
+	Provider    types.String `tfsdk:"provider_name"`
+	EntityTypes types.List   `tfsdk:"entity_types"`
+}
+
+// datamaskingEntityTypesDataSource is the data source implementation.
+type datamaskingEntityTypesDataSource struct {
+	client *hoop.Client
+}
+
+// Metadata returns the data source type name.
+func (d *datamaskingEntityTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datamasking_entity_types"
+}
+
+// Schema defines the schema for the data source.
+func (d *datamaskingEntityTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the entity types supported by the DLP provider of the gateway, " +
+			"they are the values allowed in the `supported_entity_types` attribute of the `hoop_datamasking_rules` resource.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Description: "The name of the DLP provider of the gateway.",
+				Computed:    true,
+			},
+			"entity_types": schema.ListAttribute{
+				Description: "The entity types supported by the DLP provider, sorted by name. E.g.: `EMAIL_ADDRESS`, `CREDIT_CARD`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *datamaskingEntityTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datamaskingEntityTypesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog, err := d.client.ListDatamaskingEntityTypes()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Data Masking Entity Types",
+			fmt.Sprintf("failed listing data masking entity types, reason=%v", err),
+		)
+		return
+	}
+	entityTypes := append([]string{}, catalog.EntityTypes...)
+	sort.Strings(entityTypes)
+
+	state.Provider = stringValueOrNull(catalog.Provider)
+	entityTypesList, diags := types.ListValueFrom(ctx, types.StringType, entityTypes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.EntityTypes = entityTypesList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *datamaskingEntityTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hoop.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func createFakeDatamaskingEntityTypesTestServer(fallback clientFunc) clientFunc {
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		// GET /api/datamasking-rules/entity-types endpoint
+		if req.Method == http.MethodGet && req.URL.Path == "/api/datamasking-rules/entity-types" {
+			return httpTestOk(http.StatusOK, hoop.DataMaskingEntityTypes{
+				Provider:    "mspresidio",
+				EntityTypes: []string{"PHONE_NUMBER", "EMAIL_ADDRESS", "URL", "PERSON", "CREDIT_CARD"},
+			}), nil
+		}
+		if fallback != nil {
+			return fallback(req)
+		}
+		return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+	})
+}
+
+func TestDatamaskingEntityTypesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeDatamaskingEntityTypesTestServer(nil))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_datamasking_entity_types" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hoop_datamasking_entity_types.all", "provider_name", "mspresidio"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_entity_types.all", "entity_types.#", "5"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_entity_types.all", "entity_types.0", "CREDIT_CARD"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_entity_types.all", "entity_types.1", "EMAIL_ADDRESS"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_entity_types.all", "entity_types.4", "URL"),
+				),
+			},
+		},
+	})
+}
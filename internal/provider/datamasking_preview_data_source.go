@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &datamaskingPreviewDataSource{}
+	_ datasource.DataSourceWithConfigure        = &datamaskingPreviewDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &datamaskingPreviewDataSource{}
+)
+
+// NewDatamaskingPreviewDataSource is a helper function to simplify the provider implementation.
+func NewDatamaskingPreviewDataSource() datasource.DataSource {
+	return &datamaskingPreviewDataSource{}
+}
+
+// datamaskingPreviewDataSourceModel maps the data source schema data.
+type datamaskingPreviewDataSourceModel struct {
+	Text                 types.String  `tfsdk:"text"`
+	RuleID               types.String  `tfsdk:"rule_id"`
+	ScoreThreshold       types.Float64 `tfsdk:"score_threshold"`
+	SupportedEntityTypes types.List    `tfsdk:"supported_entity_types"`
+	CustomEntityTypes    types.List    `tfsdk:"custom_entity_types"`
+	RedactedText         types.String  `tfsdk:"redacted_text"`
+	Findings             types.List    `tfsdk:"findings"`
+}
+
+var datamaskingFindingAttrTypes = map[string]attr.Type{
+	"entity_type": types.StringType,
+	"start":       types.Int64Type,
+	"end":         types.Int64Type,
+	"score":       types.Float64Type,
+	"value":       types.StringType,
+}
+
+// datamaskingPreviewDataSource is the data source implementation.
+type datamaskingPreviewDataSource struct {
+	client *hoop.Client
+}
+
+// Metadata returns the data source type name.
+func (d *datamaskingPreviewDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datamasking_preview"
+}
+
+// Schema defines the schema for the data source.
+func (d *datamaskingPreviewDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Redacts a sample text with the entities of a data masking rule, without persisting anything in the gateway. " +
+			"The entities are the ones of an existing rule, set with `rule_id`, or configured inline. " +
+			"It's useful to assert the masking behaviour of the rules with `check` blocks.",
+		Attributes: map[string]schema.Attribute{
+			"text": schema.StringAttribute{
+				Description: "The sample text to analyze and redact.",
+				Required:    true,
+				Sensitive:   true,
+				Validators:  NonEmptyStringValidator,
+			},
+			"rule_id": schema.StringAttribute{
+				Description: "The id of the data masking rule to preview. Conflicts with the inline entity configuration.",
+				Optional:    true,
+				Validators:  NonEmptyStringValidator,
+			},
+			"score_threshold": schema.Float64Attribute{
+				Description: "The minimal detection score threshold for the entities to be masked.",
+				Optional:    true,
+				Validators:  ScoreThresholdValidator,
+			},
+			"supported_entity_types": schema.ListNestedAttribute{
+				Description: "List of supported entity types",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_types": schema.ListAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "The registered entity types in the redact provider.",
+						},
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "An identifier for this structure, it's used as an identifier of a collection of entities.",
+						},
+					},
+				},
+			},
+			"custom_entity_types": schema.ListNestedAttribute{
+				Description: "The custom entity types to preview.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"deny_list": schema.ListAttribute{
+							ElementType: types.StringType,
+							Description: "List of words to be returned as PII if found.",
+							Required:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the custom entity type as uppercase.",
+							Required:    true,
+							Validators:  EntityTypeNameValidator,
+						},
+						"regex": schema.StringAttribute{
+							Description: "The regex pattern to match (python) the custom entity type.",
+							Required:    true,
+							Validators:  PythonRegexValidator,
+						},
+						"score": schema.Float64Attribute{
+							Description: "Detection confidence of this pattern (0.01 if very noisy, 0.6-1.0 if very specific)",
+							Required:    true,
+							Validators:  ScoreThresholdValidator,
+						},
+					},
+				},
+			},
+			"redacted_text": schema.StringAttribute{
+				Description: "The sample text with the detected entities masked.",
+				Computed:    true,
+			},
+			"findings": schema.ListNestedAttribute{
+				Description: "The entities detected in the sample text, in the order they appear.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_type": schema.StringAttribute{
+							Description: "The entity type detected.",
+							Computed:    true,
+						},
+						"start": schema.Int64Attribute{
+							Description: "The character offset where the entity starts in the sample text.",
+							Computed:    true,
+						},
+						"end": schema.Int64Attribute{
+							Description: "The character offset where the entity ends in the sample text, exclusive.",
+							Computed:    true,
+						},
+						"score": schema.Float64Attribute{
+							Description: "The detection score of the entity.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The text of the entity detected.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires either the id of a rule or the inline entity configuration.
+func (d *datamaskingPreviewDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("rule_id"),
+			path.MatchRoot("supported_entity_types"),
+			path.MatchRoot("custom_entity_types"),
+		),
+		datasourcevalidator.Conflicting(path.MatchRoot("rule_id"), path.MatchRoot("supported_entity_types")),
+		datasourcevalidator.Conflicting(path.MatchRoot("rule_id"), path.MatchRoot("custom_entity_types")),
+		datasourcevalidator.Conflicting(path.MatchRoot("rule_id"), path.MatchRoot("score_threshold")),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *datamaskingPreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state datamaskingPreviewDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	preview := hoop.DataMaskingPreviewRequest{
+		Text:   state.Text.ValueString(),
+		RuleID: state.RuleID.ValueString(),
+	}
+	if !state.ScoreThreshold.IsNull() {
+		scoreThreshold := state.ScoreThreshold.ValueFloat64()
+		preview.ScoreThreshold = &scoreThreshold
+	}
+	var err error
+	if !state.SupportedEntityTypes.IsNull() {
+		preview.SupportedEntityTypes, err = toApiSupportedEntityTypesList(ctx, state.SupportedEntityTypes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Converting Supported Entity Types",
+				fmt.Sprintf("Failed to convert supported entity types: %v", err),
+			)
+			return
+		}
+	}
+	if !state.CustomEntityTypes.IsNull() {
+		preview.CustomEntityTypes, err = toApiCustomEntityTypesList(ctx, state.CustomEntityTypes)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Converting Custom Entity Types",
+				fmt.Sprintf("Failed to convert custom entity types: %v", err),
+			)
+			return
+		}
+	}
+
+	result, err := d.client.PreviewDatamasking(preview)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Preview Data Masking",
+			fmt.Sprintf("failed previewing data masking, reason=%v", err),
+		)
+		return
+	}
+
+	var diags diag.Diagnostics
+	state.RedactedText = types.StringValue(result.RedactedText)
+	state.Findings, diags = fromApiDatamaskingFindings(preview.Text, result.Findings)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *datamaskingPreviewDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hoop.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// fromApiDatamaskingFindings converts the findings to a list of objects. The offsets are
+// characters of the sample text, the value is null when they are out of the text bounds.
+func fromApiDatamaskingFindings(text string, findings []hoop.DataMaskingFinding) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objectType := types.ObjectType{AttrTypes: datamaskingFindingAttrTypes}
+	textRunes := []rune(text)
+	elements := []attr.Value{}
+	for _, finding := range findings {
+		value := types.StringNull()
+		if finding.Start >= 0 && finding.Start <= finding.End && finding.End <= int64(len(textRunes)) {
+			value = types.StringValue(string(textRunes[finding.Start:finding.End]))
+		}
+		objectValue, d := types.ObjectValue(datamaskingFindingAttrTypes, map[string]attr.Value{
+			"entity_type": types.StringValue(finding.EntityType),
+			"start":       types.Int64Value(finding.Start),
+			"end":         types.Int64Value(finding.End),
+			"score":       types.Float64Value(finding.Score),
+			"value":       value,
+		})
+		diags.Append(d...)
+		elements = append(elements, objectValue)
+	}
+	if diags.HasError() {
+		return types.ListNull(objectType), diags
+	}
+	listValue, d := types.ListValue(objectType, elements)
+	diags.Append(d...)
+	return listValue, diags
+}
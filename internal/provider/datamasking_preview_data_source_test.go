@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// createFakeDatamaskingPreviewTestServer redacts the custom entity types of the request,
+// or the ones of a fake rule when the rule id is set.
+func createFakeDatamaskingPreviewTestServer() clientFunc {
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		// POST /api/datamasking-rules/preview endpoint
+		if req.Method == http.MethodPost && req.URL.Path == "/api/datamasking-rules/preview" {
+			var preview hoop.DataMaskingPreviewRequest
+			if err := json.NewDecoder(req.Body).Decode(&preview); err != nil {
+				return httpTestErr(http.StatusBadRequest, `test: unable to decode request body: %v`, err), nil
+			}
+			customEntityTypes := preview.CustomEntityTypes
+			if preview.RuleID != "" {
+				if preview.RuleID != "2b3b4f4b-1a52-4b5a-8d6f-3f0e5a3c2b1a" {
+					return httpTestErr(http.StatusNotFound, `data masking rule %q not found`, preview.RuleID), nil
+				}
+				customEntityTypes = []hoop.CustomEntityTypesEntry{{Name: "ZIP_CODE", Regex: `\d{5}`, Score: 0.8}}
+			}
+			result := hoop.DataMaskingPreview{RedactedText: preview.Text, Findings: []hoop.DataMaskingFinding{}}
+			for _, entity := range customEntityTypes {
+				re := regexp.MustCompile(entity.Regex)
+				for _, loc := range re.FindAllStringIndex(preview.Text, -1) {
+					result.Findings = append(result.Findings, hoop.DataMaskingFinding{
+						EntityType: entity.Name,
+						Start:      int64(utf8.RuneCountInString(preview.Text[:loc[0]])),
+						End:        int64(utf8.RuneCountInString(preview.Text[:loc[1]])),
+						Score:      entity.Score,
+					})
+				}
+				result.RedactedText = re.ReplaceAllString(result.RedactedText, "<"+entity.Name+">")
+			}
+			return httpTestOk(http.StatusOK, result), nil
+		}
+		return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+	})
+}
+
+func TestDatamaskingPreviewDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeDatamaskingPreviewTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_datamasking_preview" "inline" {
+  text            = "Olá, the account ACC-12345678 belongs to you"
+  score_threshold = 0.5
+
+  custom_entity_types = [
+    {
+      name      = "ACCOUNT_ID"
+      regex     = "ACC-\\d{8}"
+      deny_list = []
+      score     = 0.9
+    }
+  ]
+}
+
+data "hoop_datamasking_preview" "rule" {
+  text    = "ship it to 94103"
+  rule_id = "2b3b4f4b-1a52-4b5a-8d6f-3f0e5a3c2b1a"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "redacted_text", "Olá, the account <ACCOUNT_ID> belongs to you"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "findings.#", "1"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "findings.0.entity_type", "ACCOUNT_ID"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "findings.0.start", "17"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "findings.0.end", "29"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "findings.0.score", "0.9"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.inline", "findings.0.value", "ACC-12345678"),
+
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.rule", "redacted_text", "ship it to <ZIP_CODE>"),
+					resource.TestCheckResourceAttr("data.hoop_datamasking_preview.rule", "findings.0.value", "94103"),
+				),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_datamasking_preview" "empty" {
+  text = "ship it to 94103"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestDatamaskingFindingsValue(t *testing.T) {
+	findings, diags := fromApiDatamaskingFindings("Olá ACC-1", []hoop.DataMaskingFinding{
+		{EntityType: "ACCOUNT_ID", Start: 4, End: 9, Score: 0.9},
+		{EntityType: "OUT_OF_BOUNDS", Start: 4, End: 20, Score: 0.1},
+	})
+	if diags.HasError() {
+		t.Fatalf("failed converting findings: %v", diags)
+	}
+	elements := findings.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("expected 2 findings, got %v", len(elements))
+	}
+	if got := elements[0].(types.Object).Attributes()["value"]; got.String() != `"ACC-1"` {
+		t.Errorf("expected value to be sliced by characters, got %v", got)
+	}
+	if got := elements[1].(types.Object).Attributes()["value"]; !got.IsNull() {
+		t.Errorf("expected value out of the text bounds to be null, got %v", got)
+	}
+}
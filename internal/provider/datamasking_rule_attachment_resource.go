@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// datamaskingRuleAttachmentMaxAttempts is the number of times the connections of a rule
+// are read and written back when the rule is changed concurrently by other attachments.
+const datamaskingRuleAttachmentMaxAttempts = 3
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                 = &datamaskingRuleAttachmentResource{}
+	_ resource.ResourceWithConfigure    = &datamaskingRuleAttachmentResource{}
+	_ resource.ResourceWithImportState  = &datamaskingRuleAttachmentResource{}
+	_ resource.ResourceWithUpgradeState = &datamaskingRuleAttachmentResource{}
+)
+
+// NewDatamaskingRuleAttachmentResource is a helper function to simplify the provider implementation.
+func NewDatamaskingRuleAttachmentResource() resource.Resource {
+	return &datamaskingRuleAttachmentResource{}
+}
+
+// datamaskingRuleAttachmentResourceModel maps the resource schema data.
+type datamaskingRuleAttachmentResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	RuleID       types.String `tfsdk:"rule_id"`
+	ConnectionID types.String `tfsdk:"connection_id"`
+}
+
+// datamaskingRuleAttachmentResource is the resource implementation.
+type datamaskingRuleAttachmentResource struct {
+	client *hoop.Client
+	locks  *keyedMutex
+}
+
+// Metadata returns the resource type name.
+func (r *datamaskingRuleAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datamasking_rule_attachment"
+}
+
+// Schema defines the schema for the resource.
+func (r *datamaskingRuleAttachmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Attaches a connection to a data masking rule without managing the other connections of the rule. " +
+			"The `connection_ids` attribute of the `hoop_datamasking_rules` resource must be omitted, " +
+			"or ignored with `lifecycle { ignore_changes = [connection_ids] }`, otherwise the rule detaches the connection on the next apply.",
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the attachment, in the format `rule_id/connection_id`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rule_id": schema.StringAttribute{
+				Description: "The id of the data masking rule.",
+				Required:    true,
+				Validators:  NonEmptyStringValidator,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				Description: "The id of the connection to attach to the data masking rule.",
+				Required:    true,
+				Validators:  NonEmptyStringValidator,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Read removes the attachment from the state when the rule or the connection of the rule are gone.
+func (r *datamaskingRuleAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state datamaskingRuleAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, err := r.client.GetDatamaskingRule(state.RuleID.ValueString())
+	if hoop.IsNotFound(err) {
+		tflog.Info(ctx, "data masking rule not found, removing the attachment from the state",
+			map[string]any{"rule_id": state.RuleID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Data Masking Rule Attachment",
+			fmt.Sprintf("Failed reading data masking rule with ID %q: %v", state.RuleID.ValueString(), err),
+		)
+		return
+	}
+	if !slices.Contains(rule.ConnectionIDs, state.ConnectionID.ValueString()) {
+		tflog.Info(ctx, "connection is not attached to the data masking rule, removing the attachment from the state",
+			map[string]any{"rule_id": state.RuleID.ValueString(), "connection_id": state.ConnectionID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(datamaskingRuleAttachmentID(state.RuleID.ValueString(), state.ConnectionID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Create attaches the connection to the rule, keeping the connections attached by others.
+func (r *datamaskingRuleAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan datamaskingRuleAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectionID := plan.ConnectionID.ValueString()
+	err := r.updateRuleConnections(ctx, plan.RuleID.ValueString(), func(connectionIDs []string) ([]string, bool) {
+		if slices.Contains(connectionIDs, connectionID) {
+			return connectionIDs, false
+		}
+		return append(connectionIDs, connectionID), true
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Data Masking Rule Attachment",
+			fmt.Sprintf("Failed to attach connection %q to data masking rule %q: %v", connectionID, plan.RuleID.ValueString(), err),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(datamaskingRuleAttachmentID(plan.RuleID.ValueString(), connectionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Update is never called with changes, every attribute requires replacing the attachment.
+func (r *datamaskingRuleAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan datamaskingRuleAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete detaches the connection from the rule, keeping the connections attached by others.
+func (r *datamaskingRuleAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state datamaskingRuleAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectionID := state.ConnectionID.ValueString()
+	err := r.updateRuleConnections(ctx, state.RuleID.ValueString(), func(connectionIDs []string) ([]string, bool) {
+		remaining := slices.DeleteFunc(slices.Clone(connectionIDs), func(id string) bool { return id == connectionID })
+		return remaining, len(remaining) != len(connectionIDs)
+	})
+	// the rule was deleted, there is nothing to detach
+	if hoop.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Data Masking Rule Attachment",
+			fmt.Sprintf("Failed to detach connection %q from data masking rule %q: %v", connectionID, state.RuleID.ValueString(), err),
+		)
+		return
+	}
+}
+
+func (r *datamaskingRuleAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ruleID, connectionID, found := strings.Cut(req.ID, "/")
+	if !found || ruleID == "" || connectionID == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Import ID must be in the format 'rule_id/connection_id'",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rule_id"), ruleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_id"), connectionID)...)
+}
+
+// UpgradeState upgrades prior states of the data masking rule attachment resource to the current schema version.
+// The schema is still at its first version, there are no prior states to upgrade.
+func (r *datamaskingRuleAttachmentResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *datamaskingRuleAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+	r.locks = data.locks
+}
+
+// updateRuleConnections reads the rule, changes its connections with modify and writes it back.
+// The changes of the rule are serialized within the provider, and the rule is sent with the
+// version it was read with. When it's changed concurrently outside of the provider, the gateway
+// rejects the write and the connections are read and modified again.
+func (r *datamaskingRuleAttachmentResource) updateRuleConnections(ctx context.Context, ruleID string, modify func(connectionIDs []string) ([]string, bool)) error {
+	unlock := r.locks.Lock(datamaskingRuleLockKey(ruleID))
+	defer unlock()
+
+	var err error
+	for attempt := 1; attempt <= datamaskingRuleAttachmentMaxAttempts; attempt++ {
+		var rule *hoop.DataMaskingRule
+		rule, err = r.client.GetDatamaskingRule(ruleID)
+		if err != nil {
+			return err
+		}
+		connectionIDs, changed := modify(rule.ConnectionIDs)
+		if !changed {
+			return nil
+		}
+		rule.ConnectionIDs = connectionIDs
+		_, err = r.client.UpdateDatamaskingRule(*rule)
+		if !hoop.IsPreconditionFailed(err) {
+			return err
+		}
+		tflog.Debug(ctx, "data masking rule changed while updating its connections, retrying",
+			map[string]any{"rule_id": ruleID, "attempt": attempt})
+	}
+	return err
+}
+
+// datamaskingRuleLockKey returns the key locking the changes of the connections of a rule.
+func datamaskingRuleLockKey(ruleID string) string {
+	return "datamasking_rule/" + ruleID
+}
+
+// datamaskingRuleAttachmentID returns the identifier of the attachment of a connection to a rule.
+func datamaskingRuleAttachmentID(ruleID, connectionID string) string {
+	return ruleID + "/" + connectionID
+}
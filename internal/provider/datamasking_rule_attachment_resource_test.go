@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+const datamaskingRuleAttachmentTestConfig = `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_datamasking_rules" "shared" {
+  name                = "Shared Rule"
+  description         = "Rule shared by the application teams."
+  score_threshold     = 0.5
+  custom_entity_types = []
+  supported_entity_types = [
+    {
+      name         = "PII"
+      entity_types = ["EMAIL_ADDRESS"]
+    }
+  ]
+}
+`
+
+func TestDatamaskingRuleAttachmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeDataMaskingRulesTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: datamaskingRuleAttachmentTestConfig + `
+resource "hoop_datamasking_rule_attachment" "payments" {
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = "6f1e1b2a-4c1d-4e2f-9a3b-1c2d3e4f5a6b"
+}
+
+resource "hoop_datamasking_rule_attachment" "billing" {
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = "0b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_datamasking_rules.shared", "connection_ids.#", "0"),
+					resource.TestCheckResourceAttrPair("hoop_datamasking_rule_attachment.payments", "rule_id", "hoop_datamasking_rules.shared", "id"),
+					resource.TestCheckResourceAttr("hoop_datamasking_rule_attachment.payments", "id",
+						"c2f81d5c-8d08-4416-9205-4b88993c6ce7/6f1e1b2a-4c1d-4e2f-9a3b-1c2d3e4f5a6b"),
+					resource.TestCheckResourceAttr("hoop_datamasking_rule_attachment.billing", "id",
+						"c2f81d5c-8d08-4416-9205-4b88993c6ce7/0b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+				),
+			},
+			// The rule keeps the attached connections when it's updated
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_datamasking_rules" "shared" {
+  name                = "Shared Rule"
+  description         = "Rule shared by the application teams, updated."
+  score_threshold     = 0.5
+  custom_entity_types = []
+  supported_entity_types = [
+    {
+      name         = "PII"
+      entity_types = ["EMAIL_ADDRESS"]
+    }
+  ]
+}
+
+resource "hoop_datamasking_rule_attachment" "payments" {
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = "6f1e1b2a-4c1d-4e2f-9a3b-1c2d3e4f5a6b"
+}
+
+resource "hoop_datamasking_rule_attachment" "billing" {
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = "0b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_datamasking_rules.shared", "description", "Rule shared by the application teams, updated."),
+					resource.TestCheckResourceAttr("hoop_datamasking_rules.shared", "connection_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("hoop_datamasking_rules.shared", "connection_ids.*", "6f1e1b2a-4c1d-4e2f-9a3b-1c2d3e4f5a6b"),
+					resource.TestCheckTypeSetElemAttr("hoop_datamasking_rules.shared", "connection_ids.*", "0b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hoop_datamasking_rule_attachment.payments",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "c2f81d5c-8d08-4416-9205-4b88993c6ce7/6f1e1b2a-4c1d-4e2f-9a3b-1c2d3e4f5a6b",
+			},
+			// Detaching a connection keeps the other attachments
+			{
+				Config: datamaskingRuleAttachmentTestConfig + `
+resource "hoop_datamasking_rule_attachment" "billing" {
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = "0b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("hoop_datamasking_rule_attachment.payments", "id"),
+					resource.TestCheckResourceAttrSet("hoop_datamasking_rule_attachment.billing", "id"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_datamasking_rules.shared", "connection_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("hoop_datamasking_rules.shared", "connection_ids.*", "0b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatamaskingRuleAttachmentResourceParallel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeDataMaskingRulesTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: datamaskingRuleAttachmentTestConfig + `
+resource "hoop_datamasking_rule_attachment" "team" {
+  count         = 10
+  rule_id       = hoop_datamasking_rules.shared.id
+  connection_id = "connection-${count.index}"
+}`,
+			},
+			{
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("hoop_datamasking_rules.shared", "connection_ids.#", "10"),
+			},
+		},
+	})
+}
+
+func TestDatamaskingRuleAttachmentParallelUpdates(t *testing.T) {
+	// the fake server doesn't return versions, the connections are only kept by serializing the updates
+	rulesServer := createFakeDataMaskingRulesTestServer()
+	httpClient := clientFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := rulesServer.Do(req)
+		// give the concurrent updates the chance to read the rule before it's written back
+		if req.Method == http.MethodGet {
+			time.Sleep(10 * time.Millisecond)
+		}
+		return resp, err
+	})
+	client := hoop.NewClient("http://localhost:8009/api", "xapi-hash", httpClient)
+	rule, err := client.CreateDatamaskingRule(hoop.DataMaskingRule{Name: "Shared Rule", ConnectionIDs: []string{}})
+	if err != nil {
+		t.Fatalf("failed creating data masking rule: %v", err)
+	}
+
+	r := &datamaskingRuleAttachmentResource{client: client, locks: newKeyedMutex()}
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			connectionID := fmt.Sprintf("connection-%d", i)
+			errs <- r.updateRuleConnections(context.Background(), rule.ID, func(connectionIDs []string) ([]string, bool) {
+				return append(connectionIDs, connectionID), true
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("failed attaching connection: %v", err)
+		}
+	}
+
+	rule, err = client.GetDatamaskingRule(rule.ID)
+	if err != nil {
+		t.Fatalf("failed reading data masking rule: %v", err)
+	}
+	slices.Sort(rule.ConnectionIDs)
+	if len(rule.ConnectionIDs) != 10 || rule.ConnectionIDs[0] != "connection-0" || rule.ConnectionIDs[9] != "connection-9" {
+		t.Errorf("expected the 10 connections to be attached, got %v", rule.ConnectionIDs)
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
 )
 
@@ -21,6 +23,7 @@ import (
 var (
 	_ resource.Resource                = &datamaskingRulesResource{}
 	_ resource.ResourceWithImportState = &datamaskingRulesResource{}
+	_ resource.ResourceWithModifyPlan  = &datamaskingRulesResource{}
 )
 
 // NewDatamaskingRulesResource is a helper function to simplify the provider implementation.
@@ -36,12 +39,14 @@ type datamaskingRulesResourceModel struct {
 	ScoreThreshold       types.Float64 `tfsdk:"score_threshold"`
 	SupportedEntityTypes types.List    `tfsdk:"supported_entity_types"`
 	CustomEntityTypes    types.List    `tfsdk:"custom_entity_types"`
-	ConnectionIDs        types.List    `tfsdk:"connection_ids"`
+	ConnectionIDs        types.Set     `tfsdk:"connection_ids"`
+	DeletionProtection   types.Bool    `tfsdk:"deletion_protection"`
 }
 
 // datamaskingRulesResource is the data source implementation.
 type datamaskingRulesResource struct {
 	client *hoop.Client
+	locks  *keyedMutex
 }
 
 // Metadata returns the resource type name.
@@ -53,6 +58,7 @@ func (r *datamaskingRulesResource) Metadata(_ context.Context, req resource.Meta
 func (r *datamaskingRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Datamasking Rules resources.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the resource.",
@@ -108,23 +114,33 @@ func (r *datamaskingRulesResource) Schema(_ context.Context, _ resource.SchemaRe
 						"name": schema.StringAttribute{
 							Description: "The name of the custom entity type as uppercase.",
 							Required:    true,
+							Validators:  EntityTypeNameValidator,
 						},
 						"regex": schema.StringAttribute{
 							Description: "The regex pattern to match (python) the custom entity type.",
 							Required:    true,
+							Validators:  PythonRegexValidator,
 						},
 						"score": schema.Float64Attribute{
 							Description: "Detection confidence of this pattern (0.01 if very noisy, 0.6-1.0 if very specific)",
 							Required:    true,
+							Validators:  ScoreThresholdValidator,
 						},
 					},
 				},
 			},
-			"connection_ids": schema.ListAttribute{
+			"connection_ids": schema.SetAttribute{
 				ElementType: types.StringType,
-				Description: "List of connection IDs which this rule applies to.",
-				Required:    true,
+				Description: "Set of connection IDs which this rule applies to. " +
+					"When omitted the connections are not managed by this resource, use it with the `hoop_datamasking_rule_attachment` resource " +
+					"to attach the connections to the rule from other configurations.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
@@ -170,7 +186,8 @@ func (r *datamaskingRulesResource) Read(ctx context.Context, req resource.ReadRe
 	currentState.Name = types.StringValue(rule.Name)
 	currentState.Description = types.StringValue(rule.Description)
 	currentState.ScoreThreshold = types.Float64Value(ptrToFloat64(rule.ScoreThreshold))
-	currentState.ConnectionIDs, diags = types.ListValueFrom(ctx, types.StringType, rule.ConnectionIDs)
+	currentState.DeletionProtection = deletionProtectionValue(currentState.DeletionProtection)
+	currentState.ConnectionIDs, diags = types.SetValueFrom(ctx, types.StringType, rule.ConnectionIDs)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection IDs",
@@ -178,6 +195,10 @@ func (r *datamaskingRulesResource) Read(ctx context.Context, req resource.ReadRe
 		)
 		return
 	}
+	resp.Diagnostics.Append(setPrivateETag(ctx, resp.Private, rule.ETag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, currentState)
 	resp.Diagnostics.Append(diags...)
@@ -197,7 +218,7 @@ func (r *datamaskingRulesResource) Create(ctx context.Context, req resource.Crea
 
 	score := plan.ScoreThreshold.ValueFloat64()
 
-	connectionIDs, diags := convertListToStringSlice(ctx, plan.ConnectionIDs)
+	connectionIDs, diags := convertSetToStringSlice(ctx, plan.ConnectionIDs)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection IDs",
@@ -205,6 +226,10 @@ func (r *datamaskingRulesResource) Create(ctx context.Context, req resource.Crea
 		)
 		return
 	}
+	// the connections are attached by hoop_datamasking_rule_attachment resources
+	if connectionIDs == nil {
+		connectionIDs = []string{}
+	}
 
 	supportedEntityTypeItems := []hoop.SupportedEntityTypesEntry{}
 	var err error
@@ -249,6 +274,20 @@ func (r *datamaskingRulesResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	plan.ID = types.StringValue(rule.ID)
+	if plan.ConnectionIDs.IsUnknown() {
+		plan.ConnectionIDs, diags = types.SetValueFrom(ctx, types.StringType, connectionIDs)
+		if diags.HasError() {
+			resp.Diagnostics.AddError(
+				"Error Converting Connection IDs",
+				fmt.Sprintf("Failed to convert connection IDs: %v", diags),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(setPrivateETag(ctx, resp.Private, rule.ETag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &plan)
@@ -268,7 +307,7 @@ func (r *datamaskingRulesResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	scoreThreshold := plan.ScoreThreshold.ValueFloat64()
-	connectionIDs, diags := convertListToStringSlice(ctx, plan.ConnectionIDs)
+	connectionIDs, diags := convertSetToStringSlice(ctx, plan.ConnectionIDs)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection IDs",
@@ -302,7 +341,39 @@ func (r *datamaskingRulesResource) Update(ctx context.Context, req resource.Upda
 		}
 	}
 
-	rule, err := r.client.UpdateDatamaskingRule(hoop.DataMaskingRule{
+	etag, diags := getPrivateETag(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The connections are attached by hoop_datamasking_rule_attachment resources when they are not
+	// configured, the current connections of the rule are kept to not detach them.
+	var configConnectionIDs types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("connection_ids"), &configConnectionIDs)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keepConnections := configConnectionIDs.IsNull()
+	if keepConnections {
+		unlock := r.locks.Lock(datamaskingRuleLockKey(plan.ID.ValueString()))
+		defer unlock()
+
+		current, err := r.client.GetDatamaskingRule(plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Data Masking Rule",
+				fmt.Sprintf("Failed reading data masking rule with ID %q: %v", plan.ID.ValueString(), err),
+			)
+			return
+		}
+		connectionIDs = current.ConnectionIDs
+		if connectionIDs == nil {
+			connectionIDs = []string{}
+		}
+	}
+
+	newRule := hoop.DataMaskingRule{
 		ID:                   plan.ID.ValueString(),
 		Name:                 plan.Name.ValueString(),
 		Description:          plan.Description.ValueString(),
@@ -310,8 +381,47 @@ func (r *datamaskingRulesResource) Update(ctx context.Context, req resource.Upda
 		ConnectionIDs:        connectionIDs,
 		SupportedEntityTypes: supportedEntityTypeItems,
 		CustomEntityTypes:    customEntityTypeItems,
-	})
+		ETag:                 etag,
+	}
+	rule, err := r.client.UpdateDatamaskingRule(newRule)
+
+	// The attachments applied after the rule was read change its version without conflicting
+	// with this update, it's retried with the current version when only the connections changed.
+	if hoop.IsPreconditionFailed(err) && keepConnections {
+		var state datamaskingRulesResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current, err := r.client.GetDatamaskingRule(plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating Data Masking Rule",
+				fmt.Sprintf("Failed reading data masking rule with ID %q: %v", plan.ID.ValueString(), err),
+			)
+			return
+		}
+		unchanged, diags := datamaskingRuleMatchesState(current, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if unchanged {
+			newRule.ConnectionIDs, newRule.ETag = current.ConnectionIDs, current.ETag
+			if newRule.ConnectionIDs == nil {
+				newRule.ConnectionIDs = []string{}
+			}
+			rule, err = r.client.UpdateDatamaskingRule(newRule)
+		}
+	}
 
+	if hoop.IsPreconditionFailed(err) {
+		resp.Diagnostics.AddError(
+			"Error Updating Data Masking Rule",
+			preconditionFailedDetail(fmt.Sprintf("data masking rule %q", plan.Name.ValueString())),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Data Masking Rule",
@@ -324,6 +434,10 @@ func (r *datamaskingRulesResource) Update(ctx context.Context, req resource.Upda
 	plan.Name = types.StringValue(rule.Name)
 	plan.Description = types.StringValue(rule.Description)
 	plan.ScoreThreshold = types.Float64Value(*rule.ScoreThreshold)
+	resp.Diagnostics.Append(setPrivateETag(ctx, resp.Private, rule.ETag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -341,7 +455,29 @@ func (r *datamaskingRulesResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	if err := r.client.DeleteDatamaskingRule(state.ID.ValueString()); err != nil {
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Error Deleting Data Masking Rule",
+			deletionProtectionErrorDetail(fmt.Sprintf("data masking rule %q", state.Name.ValueString())),
+		)
+		return
+	}
+
+	etag, diags := getPrivateETag(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDatamaskingRule(state.ID.ValueString(), etag)
+	if hoop.IsPreconditionFailed(err) {
+		resp.Diagnostics.AddError(
+			"Error Deleting Data Masking Rule",
+			preconditionFailedDetail(fmt.Sprintf("data masking rule %q", state.Name.ValueString())),
+		)
+		return
+	}
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Data Masking Rule",
 			fmt.Sprintf("Failed to delete data masking rule with ID %q: %v", state.ID.ValueString(), err),
@@ -350,8 +486,71 @@ func (r *datamaskingRulesResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+// ModifyPlan warns when a rule protected against deletion is planned to be replaced
+// and rejects entity types that are not supported by the DLP provider of the gateway.
+func (r *datamaskingRulesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDeletionProtectionReplace(ctx, req, resp, path.Root("name"))
+
+	// the resource is being destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var supportedEntityTypes types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("supported_entity_types"), &supportedEntityTypes)...)
+	if resp.Diagnostics.HasError() || supportedEntityTypes.IsNull() || supportedEntityTypes.IsUnknown() {
+		return
+	}
+
+	catalog, err := r.client.ListDatamaskingEntityTypes()
+	if err != nil {
+		tflog.Debug(ctx, "skipping the validation of the entity types, unable to list the entity types of the gateway",
+			map[string]any{"error": err.Error()})
+		return
+	}
+	// gateways without a DLP provider return an empty catalog
+	if len(catalog.EntityTypes) == 0 {
+		return
+	}
+	supported := map[string]struct{}{}
+	for _, entityType := range catalog.EntityTypes {
+		supported[entityType] = struct{}{}
+	}
+	for i, elem := range supportedEntityTypes.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		entityTypes, ok := obj.Attributes()["entity_types"].(types.List)
+		if !ok || entityTypes.IsUnknown() {
+			continue
+		}
+		for j, entityTypeElem := range entityTypes.Elements() {
+			entityType, ok := entityTypeElem.(types.String)
+			if !ok || entityType.IsUnknown() || entityType.IsNull() {
+				continue
+			}
+			if _, ok := supported[entityType.ValueString()]; ok {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("supported_entity_types").AtListIndex(i).AtName("entity_types").AtListIndex(j),
+				"Unsupported Entity Type",
+				fmt.Sprintf("The entity type %q is not supported by the DLP provider of the gateway, it would be ignored when masking. "+
+					"Use the hoop_datamasking_entity_types data source to list the supported entity types.", entityType.ValueString()),
+			)
+		}
+	}
+}
+
+// ImportState imports a data masking rule by its id or by its name with the name: prefix.
 func (r *datamaskingRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "Data Masking Rule", func(name string) (string, error) {
+		rule, err := r.client.GetDatamaskingRuleByName(name)
+		if err != nil {
+			return "", err
+		}
+		return rule.ID, nil
+	})
 }
 
 // Configure adds the provider configured client to the data source.
@@ -362,16 +561,35 @@ func (r *datamaskingRulesResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.locks = data.locks
+}
+
+// datamaskingRuleMatchesState reports whether the rule has the attributes kept in the state,
+// the connections are not compared since they may be attached by other resources.
+func datamaskingRuleMatchesState(rule *hoop.DataMaskingRule, state datamaskingRulesResourceModel) (bool, diag.Diagnostics) {
+	supportedEntityTypes, diags := fromApiSupportedEntityTypesList(rule.SupportedEntityTypes)
+	if diags.HasError() {
+		return false, diags
+	}
+	customEntityTypes, diags := fromApiCustomEntityTypesList(rule.CustomEntityTypes)
+	if diags.HasError() {
+		return false, diags
+	}
+	return rule.Name == state.Name.ValueString() &&
+		rule.Description == state.Description.ValueString() &&
+		ptrToFloat64(rule.ScoreThreshold) == state.ScoreThreshold.ValueFloat64() &&
+		supportedEntityTypes.Equal(state.SupportedEntityTypes) &&
+		customEntityTypes.Equal(state.CustomEntityTypes), nil
 }
 
 func ptrToFloat64(f *float64) float64 {
@@ -2,11 +2,16 @@ package provider
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
@@ -15,7 +20,19 @@ import (
 func createFakeDataMaskingRulesTestServer() clientFunc {
 	store := map[string]*hoop.DataMaskingRule{}
 	resourceID := "c2f81d5c-8d08-4416-9205-4b88993c6ce7"
+	// the attachments of a rule are applied in parallel
+	var mu sync.Mutex
 	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		// GET /api/datamasking-rules endpoint
+		if req.Method == http.MethodGet && req.URL.Path == "/api/datamasking-rules" {
+			rules := []*hoop.DataMaskingRule{}
+			for _, rule := range store {
+				rules = append(rules, rule)
+			}
+			return httpTestOk(http.StatusOK, rules), nil
+		}
 		switch req.Method {
 		// POST /api/datamasking-rules endpoint
 
@@ -107,7 +124,7 @@ resource "hoop_datamasking_rules" "rule1" {
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "name", "Example Rule 1"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "description", "This is an example datamasking rule 1."),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "score_threshold", "0.5"),
-					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "connection_ids.0", "c2f81d5c-8d08-4416-9205-4b88993c6ce7"),
+					resource.TestCheckTypeSetElemAttr("hoop_datamasking_rules.rule1", "connection_ids.*", "c2f81d5c-8d08-4416-9205-4b88993c6ce7"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.name", "PII"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.entity_types.0", "EMAIL_ADDRESS"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.entity_types.1", "PHONE_NUMBER"),
@@ -155,7 +172,7 @@ resource "hoop_datamasking_rules" "rule1" {
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "name", "Example Rule 1"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "description", "This is an example datamasking rule 1."),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "score_threshold", "0.91"),
-					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "connection_ids.0", "c2f81d5c-8d08-4416-9205-4b88993c6ce7"),
+					resource.TestCheckTypeSetElemAttr("hoop_datamasking_rules.rule1", "connection_ids.*", "c2f81d5c-8d08-4416-9205-4b88993c6ce7"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.name", "PII"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.entity_types.0", "EMAIL_ADDRESS"),
 					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.entity_types.1", "URL"),
@@ -174,6 +191,152 @@ resource "hoop_datamasking_rules" "rule1" {
 				ImportStateVerify: true,
 				ImportStateId:     "c2f81d5c-8d08-4416-9205-4b88993c6ce7",
 			},
+			// ImportState testing by name
+			{
+				ResourceName:      "hoop_datamasking_rules.rule1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "name:Example Rule 1",
+			},
 		},
 	})
 }
+
+func TestDataMaskingRulesResourceEntityTypes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeDatamaskingEntityTypesTestServer(createFakeDataMaskingRulesTestServer()))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_datamasking_rules" "rule1" {
+  name                   = "Example Rule 1"
+  score_threshold        = 0.5
+  connection_ids         = []
+  custom_entity_types    = []
+  supported_entity_types = [
+    {
+      name         = "PII"
+      entity_types = ["EMAIL_ADDRESS", "EMAIL_ADRESS"]
+    }
+  ]
+}`,
+				ExpectError: regexp.MustCompile(`Unsupported Entity Type`),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_datamasking_rules" "rule1" {
+  name                   = "Example Rule 1"
+  score_threshold        = 0.5
+  connection_ids         = []
+  custom_entity_types    = []
+  supported_entity_types = [
+    {
+      name         = "PII"
+      entity_types = ["EMAIL_ADDRESS", "PHONE_NUMBER"]
+    }
+  ]
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_datamasking_rules.rule1", "supported_entity_types.0.entity_types.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataMaskingRulesResourceDeletionProtectionReplace(t *testing.T) {
+	state := func(name, description string) string {
+		return fmt.Sprintf(`{
+  "id": "2b7f0b5c-5a1e-4b0f-8f57-3c9a5d7e1f20",
+  "name": %q,
+  "description": %q,
+  "score_threshold": 0.6,
+  "supported_entity_types": null,
+  "custom_entity_types": null,
+  "connection_ids": null,
+  "deletion_protection": true
+}`, name, description)
+	}
+	priorState := state("pii-rule", "Masks PII")
+
+	for _, tt := range []struct {
+		name             string
+		proposedNewState string
+		wantWarning      bool
+	}{
+		{"replace name", state("pii-rule-v2", "Masks PII"), true},
+		{"update description", state("pii-rule", "Masks personal data"), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := planTestResourceChange(t, NewDatamaskingRulesResource, priorState, tt.proposedNewState)
+			if hasWarning := hasTestDiagnostic(resp.Diagnostics, tfprotov6.DiagnosticSeverityWarning, "Resource Replacement Blocked by Deletion Protection"); hasWarning != tt.wantWarning {
+				t.Errorf("expected deletion protection warning to be %v, got diagnostics %v", tt.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDataMaskingRuleMatchesState(t *testing.T) {
+	scoreThreshold := 0.6
+	stateRule := &hoop.DataMaskingRule{
+		Name:                 "pii-rule",
+		Description:          "Masks PII",
+		ScoreThreshold:       &scoreThreshold,
+		ConnectionIDs:        []string{"conn-1"},
+		SupportedEntityTypes: []hoop.SupportedEntityTypesEntry{{Name: "PII", EntityTypes: []string{"EMAIL_ADDRESS"}}},
+		CustomEntityTypes:    []hoop.CustomEntityTypesEntry{},
+	}
+	supportedEntityTypes, diags := fromApiSupportedEntityTypesList(stateRule.SupportedEntityTypes)
+	if diags.HasError() {
+		t.Fatalf("failed converting supported entity types: %v", diags)
+	}
+	customEntityTypes, diags := fromApiCustomEntityTypesList(stateRule.CustomEntityTypes)
+	if diags.HasError() {
+		t.Fatalf("failed converting custom entity types: %v", diags)
+	}
+	state := datamaskingRulesResourceModel{
+		Name:                 types.StringValue(stateRule.Name),
+		Description:          types.StringValue(stateRule.Description),
+		ScoreThreshold:       types.Float64Value(scoreThreshold),
+		SupportedEntityTypes: supportedEntityTypes,
+		CustomEntityTypes:    customEntityTypes,
+	}
+
+	for name, tt := range map[string]struct {
+		change    func(rule *hoop.DataMaskingRule)
+		wantMatch bool
+	}{
+		"unchanged":           {change: func(rule *hoop.DataMaskingRule) {}, wantMatch: true},
+		"attached connection": {change: func(rule *hoop.DataMaskingRule) { rule.ConnectionIDs = append(rule.ConnectionIDs, "conn-2") }, wantMatch: true},
+		"changed description": {change: func(rule *hoop.DataMaskingRule) { rule.Description = "Masks emails" }},
+		"changed entity types": {change: func(rule *hoop.DataMaskingRule) {
+			rule.SupportedEntityTypes = []hoop.SupportedEntityTypesEntry{{Name: "PII", EntityTypes: []string{"PHONE_NUMBER"}}}
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rule := *stateRule
+			rule.ConnectionIDs = slices.Clone(stateRule.ConnectionIDs)
+			tt.change(&rule)
+			match, diags := datamaskingRuleMatchesState(&rule, state)
+			if diags.HasError() {
+				t.Fatalf("failed comparing the rule: %v", diags)
+			}
+			if match != tt.wantMatch {
+				t.Errorf("expected match=%v, got %v", tt.wantMatch, match)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &datamaskingRulesResource{}
+
+// datamaskingRulesResourceModelV0 maps the schema version 0 of the data masking rules resource,
+// where connection_ids was a list.
+type datamaskingRulesResourceModelV0 struct {
+	ID                   types.String  `tfsdk:"id"`
+	Name                 types.String  `tfsdk:"name"`
+	Description          types.String  `tfsdk:"description"`
+	ScoreThreshold       types.Float64 `tfsdk:"score_threshold"`
+	SupportedEntityTypes types.List    `tfsdk:"supported_entity_types"`
+	CustomEntityTypes    types.List    `tfsdk:"custom_entity_types"`
+	ConnectionIDs        types.List    `tfsdk:"connection_ids"`
+}
+
+// datamaskingRulesSchemaV0 is the schema version 0 of the data masking rules resource.
+// It must not be changed, it's only used to decode prior states.
+func datamaskingRulesSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":              schema.StringAttribute{Computed: true},
+			"name":            schema.StringAttribute{Required: true},
+			"description":     schema.StringAttribute{Required: true},
+			"score_threshold": schema.Float64Attribute{Required: true},
+			"supported_entity_types": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"entity_types": schema.ListAttribute{Required: true, ElementType: types.StringType},
+						"name":         schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"custom_entity_types": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"deny_list": schema.ListAttribute{Required: true, ElementType: types.StringType},
+						"name":      schema.StringAttribute{Required: true},
+						"regex":     schema.StringAttribute{Required: true},
+						"score":     schema.Float64Attribute{Required: true},
+					},
+				},
+			},
+			"connection_ids": schema.ListAttribute{Required: true, ElementType: types.StringType},
+		},
+	}
+}
+
+// UpgradeState upgrades prior states of the data masking rules resource to the current schema version.
+func (r *datamaskingRulesResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: datamaskingRulesSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState datamaskingRulesResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState, diags := upgradeDatamaskingRulesStateV0(ctx, priorState)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+	}
+}
+
+// upgradeDatamaskingRulesStateV0 converts the connection_ids list of the schema version 0 to a set.
+func upgradeDatamaskingRulesStateV0(ctx context.Context, prior datamaskingRulesResourceModelV0) (state datamaskingRulesResourceModel, diags diag.Diagnostics) {
+	state = datamaskingRulesResourceModel{
+		ID:                   prior.ID,
+		Name:                 prior.Name,
+		Description:          prior.Description,
+		ScoreThreshold:       prior.ScoreThreshold,
+		SupportedEntityTypes: prior.SupportedEntityTypes,
+		CustomEntityTypes:    prior.CustomEntityTypes,
+		DeletionProtection:   types.BoolValue(false),
+	}
+	state.ConnectionIDs, diags = convertListToSet(ctx, prior.ConnectionIDs)
+	return
+}
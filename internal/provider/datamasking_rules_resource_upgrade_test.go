@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDatamaskingRulesResourceUpgradeStateV0(t *testing.T) {
+	var state datamaskingRulesResourceModel
+	upgradeTestState(t, NewDatamaskingRulesResource, 0, `{
+  "id": "f3b3a2a1-7a0e-4b5b-8d6a-2b7c6b1f0e21",
+  "name": "pii",
+  "description": "masks pii data",
+  "score_threshold": 0.6,
+  "supported_entity_types": [{"name": "PII", "entity_types": ["EMAIL_ADDRESS", "PHONE_NUMBER"]}],
+  "custom_entity_types": [],
+  "connection_ids": ["c2f81d5c-8d08-4416-9205-4b88993c6ce7"]
+}`, &state)
+
+	if state.ScoreThreshold.ValueFloat64() != 0.6 || len(state.SupportedEntityTypes.Elements()) != 1 {
+		t.Errorf("expected attributes to be preserved, got score_threshold=%v, supported_entity_types=%v",
+			state.ScoreThreshold, state.SupportedEntityTypes)
+	}
+
+	connectionIDs, diags := convertSetToStringSlice(context.Background(), state.ConnectionIDs)
+	if diags.HasError() {
+		t.Fatalf("failed converting connection ids: %v", diags)
+	}
+	if len(connectionIDs) != 1 || connectionIDs[0] != "c2f81d5c-8d08-4416-9205-4b88993c6ce7" {
+		t.Errorf("expected connection ids to be a set of [c2f81d5c-8d08-4416-9205-4b88993c6ce7], got %v", connectionIDs)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute is the schema attribute preventing a resource from being deleted.
+// It's only kept in the Terraform state, the gateway is not aware of it.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Prevents the resource from being deleted or replaced by Terraform. " +
+			"It must be set to `false` and applied before the resource can be destroyed. Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// deletionProtectionValue returns the value of the deletion protection kept in the state,
+// prior states and imported resources don't have it set.
+func deletionProtectionValue(v types.Bool) types.Bool {
+	if v.IsNull() || v.IsUnknown() {
+		return types.BoolValue(false)
+	}
+	return v
+}
+
+// deletionProtectionErrorDetail describes a delete blocked by the deletion protection.
+func deletionProtectionErrorDetail(resourceName string) string {
+	return fmt.Sprintf("The %s has deletion_protection enabled. "+
+		"Set deletion_protection = false and apply the change before destroying or replacing it.", resourceName)
+}
+
+// warnDeletionProtectionReplace warns when a resource with the deletion protection
+// enabled is planned to be replaced, the delete step will fail when applying it.
+// The replacement is computed from the attributes requiring it, the plan modifiers
+// of the framework only report them after ModifyPlan is called.
+func warnDeletionProtectionReplace(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, replaceAttributes ...path.Path) {
+	// the resource is being created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if !deletionProtection.ValueBool() {
+		return
+	}
+
+	var replaced path.Paths
+	for _, p := range replaceAttributes {
+		var stateValue, planValue attr.Value
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &stateValue)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planValue)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planValue.Equal(stateValue) {
+			replaced = append(replaced, p)
+		}
+	}
+	if len(replaced) == 0 {
+		return
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("deletion_protection"),
+		"Resource Replacement Blocked by Deletion Protection",
+		fmt.Sprintf("This change requires replacing the resource, which has deletion_protection enabled. "+
+			"The apply will fail when deleting it, set deletion_protection = false first. Attributes requiring replacement: %v", replaced),
+	)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// etagPrivateKey is the private state key holding the version of the resource
+// returned by the gateway. It's sent back in the If-Match header when the
+// resource is updated or deleted.
+const etagPrivateKey = "etag"
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// getPrivateETag returns the version of the resource stored in the private state,
+// an empty value means the version is unknown.
+func getPrivateETag(ctx context.Context, private privateStateGetter) (etag string, diags diag.Diagnostics) {
+	data, diags := private.GetKey(ctx, etagPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return "", diags
+	}
+	if err := json.Unmarshal(data, &etag); err != nil {
+		diags.AddError(
+			"Error Reading Private State",
+			fmt.Sprintf("Failed to decode the resource version: %v", err),
+		)
+	}
+	return etag, diags
+}
+
+// setPrivateETag stores the version of the resource in the private state,
+// the key is removed when the gateway didn't return a version.
+func setPrivateETag(ctx context.Context, private privateStateSetter, etag string) diag.Diagnostics {
+	if etag == "" {
+		return private.SetKey(ctx, etagPrivateKey, nil)
+	}
+	data, err := json.Marshal(etag)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Error Writing Private State",
+			fmt.Sprintf("Failed to encode the resource version: %v", err),
+		)
+		return diags
+	}
+	return private.SetKey(ctx, etagPrivateKey, data)
+}
+
+// preconditionFailedDetail describes a write rejected because the resource
+// has changed in the gateway after the plan was created.
+func preconditionFailedDetail(resourceName string) string {
+	return fmt.Sprintf("The %s has changed since the plan was created, re-run plan to review the changes before applying them. "+
+		"Set optimistic_locking = false in the provider configuration to disable this check.", resourceName)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importByNamePrefix is the prefix of the import ids identifying a resource by its name instead of its id.
+const importByNamePrefix = "name:"
+
+// importStateByIDOrName imports a resource by its id, or by its name when the import id
+// has the name: prefix. The id of the resource with the name is resolved with lookupID.
+func importStateByIDOrName(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+	resourceName string, lookupID func(name string) (string, error)) {
+	name, byName := strings.CutPrefix(req.ID, importByNamePrefix)
+	if !byName {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID must be the id of the %s or in the format '%s<name>'", strings.ToLower(resourceName), importByNamePrefix),
+		)
+		return
+	}
+	id, err := lookupID(name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing "+resourceName,
+			fmt.Sprintf("Failed to find the %s with name %q: %v", strings.ToLower(resourceName), name, err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import "sync"
+
+// keyedMutex serializes the operations sharing the same key across the resources of the provider,
+// e.g.: the read-modify-write of a gateway object changed by several terraform resources.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// newKeyedMutex returns a keyedMutex without any lock held.
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: map[string]*sync.Mutex{}}
+}
+
+// Lock locks the key and returns the function to unlock it.
+func (m *keyedMutex) Lock(key string) (unlock func()) {
+	m.mu.Lock()
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
@@ -17,9 +17,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &pluginConfigResource{}
-	_ resource.ResourceWithConfigure   = &pluginConfigResource{}
-	_ resource.ResourceWithImportState = &pluginConfigResource{}
+	_ resource.Resource                 = &pluginConfigResource{}
+	_ resource.ResourceWithConfigure    = &pluginConfigResource{}
+	_ resource.ResourceWithImportState  = &pluginConfigResource{}
+	_ resource.ResourceWithUpgradeState = &pluginConfigResource{}
 )
 
 // NewPluginConfigResource is a helper function to simplify the provider implementation.
@@ -47,7 +48,8 @@ func (r *pluginConfigResource) Metadata(_ context.Context, req resource.Metadata
 // Schema defines the schema for the data source.
 func (r *pluginConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Plugin Config resources allows configuring plugin definitions. The supported plugins that accept configurations are: `slack`, and `runbooks` (DEPRECATED). Make sure to work with this resource only with the gateway version 1.39.1 and onwards.",
+		Description: "Plugin Config resources allows configuring plugin definitions. The supported plugins that accept configurations are: `slack`, and `runbooks` (DEPRECATED). Make sure to work with this resource only with the gateway version 1.39.1 and onwards. Prefer the `hoop_slack_plugin` resource to configure the `slack` plugin.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the resource.",
@@ -222,6 +224,12 @@ func (r *pluginConfigResource) ImportState(ctx context.Context, req resource.Imp
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plugin_name"), pluginName)...)
 }
 
+// UpgradeState upgrades prior states of the plugin config resource to the current schema version.
+// The schema is still at its first version, there are no prior states to upgrade.
+func (r *pluginConfigResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure adds the provider configured client to the data source.
 func (r *pluginConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -230,14 +238,14 @@ func (r *pluginConfigResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
@@ -19,9 +19,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &pluginConnectionResource{}
-	_ resource.ResourceWithConfigure   = &pluginConnectionResource{}
-	_ resource.ResourceWithImportState = &pluginConnectionResource{}
+	_ resource.Resource                 = &pluginConnectionResource{}
+	_ resource.ResourceWithConfigure    = &pluginConnectionResource{}
+	_ resource.ResourceWithImportState  = &pluginConnectionResource{}
+	_ resource.ResourceWithUpgradeState = &pluginConnectionResource{}
 )
 
 // NewPluginConnectionResource is a helper function to simplify the provider implementation.
@@ -50,6 +51,7 @@ func (r *pluginConnectionResource) Metadata(_ context.Context, req resource.Meta
 func (r *pluginConnectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Plugin Connection resources allows enabling features specific features to connection which are called plugins. The supported plugins are: `slack`, `webhooks`, `runbooks` (DEPRECATED), `access_control`.",
+		Version:     0,
 		Attributes: map[string]schema.Attribute{
 			"plugin_name": schema.StringAttribute{
 				Description: "The name of the plugin that this configuration refers to. Accepted values are: `slack`, `webhooks`, `runbooks` (DEPRECATED), `access_control`.",
@@ -238,6 +240,12 @@ func (r *pluginConnectionResource) ImportState(ctx context.Context, req resource
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("connection_id"), connectionID)...)
 }
 
+// UpgradeState upgrades prior states of the plugin connection resource to the current schema version.
+// The schema is still at its first version, there are no prior states to upgrade.
+func (r *pluginConnectionResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
 // Configure adds the provider configured client to the data source.
 func (r *pluginConnectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -246,14 +254,14 @@ func (r *pluginConnectionResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -21,7 +22,8 @@ The Hoop provider allows managing resources from a Hoop Gateway instance API.
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &hoopProvider{}
+	_ provider.Provider                       = &hoopProvider{}
+	_ provider.ProviderWithEphemeralResources = &hoopProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -44,8 +46,19 @@ type hoopProvider struct {
 
 // hashicupsProviderModel maps provider schema data to a Go type.
 type hoopProviderModel struct {
-	ApiURL types.String `tfsdk:"api_url"`
-	ApiKey types.String `tfsdk:"api_key"`
+	ApiURL            types.String `tfsdk:"api_url"`
+	ApiKey            types.String `tfsdk:"api_key"`
+	OptimisticLocking types.Bool   `tfsdk:"optimistic_locking"`
+	DefaultTags       types.Map    `tfsdk:"default_tags"`
+	IgnoreTags        types.Object `tfsdk:"ignore_tags"`
+}
+
+// providerData is made available to the resources when configuring them.
+type providerData struct {
+	client *hoop.Client
+	tags   tagsConfig
+	// locks serializes the changes of the gateway objects shared by several resources
+	locks *keyedMutex
 }
 
 // Metadata returns the provider type name.
@@ -68,6 +81,32 @@ func (p *hoopProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"optimistic_locking": schema.BoolAttribute{
+				Description: "Send the version of the resources read from the Hoop Gateway when updating or deleting them, " +
+					"failing the apply if they have changed since the plan was created. Defaults to `true`.",
+				Optional: true,
+			},
+			"default_tags": schema.MapAttribute{
+				Description: "A map of tags merged into the tags of every connection. The tags of the connection take precedence over them.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"ignore_tags": schema.SingleNestedAttribute{
+				Description: "Connection tags managed outside of Terraform. They are not reported as drift and are kept when updating connections.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"keys": schema.SetAttribute{
+						Description: "Tag keys to ignore.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"key_prefixes": schema.SetAttribute{
+						Description: "Tag key prefixes to ignore.",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -103,6 +142,24 @@ func (p *hoopProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		)
 	}
 
+	if config.DefaultTags.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown Default Tags",
+			"The provider cannot be configured as there is an unknown configuration value for the default tags. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.IgnoreTags.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ignore_tags"),
+			"Unknown Ignore Tags",
+			"The provider cannot be configured as there is an unknown configuration value for the ignore tags. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -148,16 +205,33 @@ func (p *hoopProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 
 	client := hoop.NewClient(apiURL, apiKey, p.httpClient)
+	if !config.OptimisticLocking.IsNull() && !config.OptimisticLocking.IsUnknown() {
+		client.SetOptimisticLocking(config.OptimisticLocking.ValueBool())
+	}
+
+	tags, diags := newTagsConfig(ctx, config.DefaultTags, config.IgnoreTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Make the HashiCups client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &providerData{client: client, tags: tags, locks: newKeyedMutex()}
+	resp.EphemeralResourceData = client
 
 }
 
 // DataSources defines the data sources implemented in the provider.
-func (p *hoopProvider) DataSources(_ context.Context) []func() datasource.DataSource { return nil }
+func (p *hoopProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewRunbooksDataSource,
+		NewRunbookConfigurationsDataSource,
+		NewDatamaskingPreviewDataSource,
+		NewDatamaskingEntityTypesDataSource,
+	}
+}
 
 // Resources defines the resources implemented in the provider.
 func (p *hoopProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -165,9 +239,18 @@ func (p *hoopProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewConnectionResource,
 		NewPluginConnectionResource,
 		NewPluginConfigResource,
+		NewSlackPluginResource,
 		NewDatamaskingRulesResource,
+		NewDatamaskingRuleAttachmentResource,
 		NewRunbookConfigurationResource,
 		NewRunbookRulesResource,
 		NewUserResource,
 	}
 }
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *hoopProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewConnectionCredentialsEphemeralResource,
+	}
+}
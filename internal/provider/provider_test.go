@@ -4,10 +4,18 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 type clientFunc func(req *http.Request) (*http.Response, error)
@@ -36,3 +44,146 @@ func httpTestOk(statusCode int, obj any) *http.Response {
 		Body:       io.NopCloser(buf),
 	}
 }
+
+// upgradeTestState runs the upgrade state RPC of the provider for a prior state in
+// the JSON format persisted by Terraform and decodes the upgraded state into target.
+func upgradeTestState(t *testing.T, newResource func() resource.Resource, version int64, priorState string, target any) {
+	t.Helper()
+	ctx := context.Background()
+
+	server := providerserver.NewProtocol6(New("test", nil)())()
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: testResourceTypeName(newResource()),
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(priorState)},
+	})
+	if err != nil {
+		t.Fatalf("failed upgrading state: %v", err)
+	}
+	assertNoDiagnosticErrors(t, resp.Diagnostics)
+	decodeTestState(t, newResource(), resp.UpgradedState, target)
+}
+
+// moveTestState runs the move state RPC of the provider for a source state in the JSON
+// format persisted by Terraform and decodes the target state into target.
+func moveTestState(t *testing.T, sourceTypeName string, newResource func() resource.Resource, sourceState string, target any) *tfprotov6.MoveResourceStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	server := providerserver.NewProtocol6(New("test", nil)())()
+	resp, err := server.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: "registry.terraform.io/hoophq/hoop",
+		SourceTypeName:        sourceTypeName,
+		SourceSchemaVersion:   0,
+		SourceState:           &tfprotov6.RawState{JSON: []byte(sourceState)},
+		TargetTypeName:        testResourceTypeName(newResource()),
+	})
+	if err != nil {
+		t.Fatalf("failed moving state: %v", err)
+	}
+	assertNoDiagnosticErrors(t, resp.Diagnostics)
+	decodeTestState(t, newResource(), resp.TargetState, target)
+	return resp
+}
+
+// planTestResourceChange runs the plan resource change RPC of the provider for a prior
+// state and a proposed new state in the JSON format persisted by Terraform, the proposed
+// new state is also used as the configuration of the resource.
+func planTestResourceChange(t *testing.T, newResource func() resource.Resource, priorState, proposedNewState string) *tfprotov6.PlanResourceChangeResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	newResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+	dynamicValue := func(state string) *tfprotov6.DynamicValue {
+		val, err := tftypes.ValueFromJSON([]byte(state), schemaType)
+		if err != nil {
+			t.Fatalf("failed decoding state: %v", err)
+		}
+		dv, err := tfprotov6.NewDynamicValue(schemaType, val)
+		if err != nil {
+			t.Fatalf("failed encoding state: %v", err)
+		}
+		return &dv
+	}
+
+	server := providerserver.NewProtocol6(New("test", nil)())()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         testResourceTypeName(newResource()),
+		PriorState:       dynamicValue(priorState),
+		ProposedNewState: dynamicValue(proposedNewState),
+		Config:           dynamicValue(proposedNewState),
+	})
+	if err != nil {
+		t.Fatalf("failed planning resource change: %v", err)
+	}
+	assertNoDiagnosticErrors(t, resp.Diagnostics)
+	return resp
+}
+
+func testResourceTypeName(res resource.Resource) string {
+	metadataResp := resource.MetadataResponse{}
+	res.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "hoop"}, &metadataResp)
+	return metadataResp.TypeName
+}
+
+func assertNoDiagnosticErrors(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func hasTestDiagnostic(diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity, summary string) bool {
+	for _, d := range diags {
+		if d.Severity == severity && d.Summary == summary {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeTestState(t *testing.T, res resource.Resource, state *tfprotov6.DynamicValue, target any) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	rawState, err := state.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("failed decoding state: %v", err)
+	}
+	tfState := tfsdk.State{Schema: schemaResp.Schema, Raw: rawState}
+	if diags := tfState.Get(ctx, target); diags.HasError() {
+		t.Fatalf("failed decoding state: %v", diags)
+	}
+}
+
+func TestResourcesUpgradeState(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range New("test", nil)().Resources(ctx) {
+		res := newResource()
+		typeName := testResourceTypeName(res)
+		t.Run(typeName, func(t *testing.T) {
+			upgrader, ok := res.(resource.ResourceWithUpgradeState)
+			if !ok {
+				t.Fatalf("resource %s must implement resource.ResourceWithUpgradeState", typeName)
+			}
+			schemaResp := resource.SchemaResponse{}
+			res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			upgraders := upgrader.UpgradeState(ctx)
+			for version := int64(0); version < schemaResp.Schema.Version; version++ {
+				if _, ok := upgraders[version]; !ok {
+					t.Errorf("missing state upgrader from version %v to %v", version, schemaResp.Schema.Version)
+				}
+			}
+			if len(upgraders) != int(schemaResp.Schema.Version) {
+				t.Errorf("expected %v state upgraders, got %v", schemaResp.Schema.Version, len(upgraders))
+			}
+		})
+	}
+}
@@ -6,19 +6,27 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &runbookConfigurationResource{}
-	_ resource.ResourceWithImportState = &runbookConfigurationResource{}
+	_ resource.Resource                 = &runbookConfigurationResource{}
+	_ resource.ResourceWithImportState  = &runbookConfigurationResource{}
+	_ resource.ResourceWithUpgradeState = &runbookConfigurationResource{}
+	_ resource.ResourceWithModifyPlan   = &runbookConfigurationResource{}
 )
 
 // NewRunbookConfigurationResource is a helper function to simplify the provider implementation.
@@ -28,15 +36,42 @@ func NewRunbookConfigurationResource() resource.Resource {
 
 // runbookConfigurationResourceModel maps the data source schema data.
 type runbookConfigurationResourceModel struct {
-	Repository    types.String `tfsdk:"repository"`
-	GitURL        types.String `tfsdk:"git_url"`
-	GitUser       types.String `tfsdk:"git_user"`
-	GitPassword   types.String `tfsdk:"git_password"`
-	GitHookTTL    types.Int32  `tfsdk:"git_hook_ttl"`
-	SSHUser       types.String `tfsdk:"ssh_user"`
-	SSHKey        types.String `tfsdk:"ssh_key"`
-	SSHKeyPass    types.String `tfsdk:"ssh_keypass"`
-	SSHKnownHosts types.String `tfsdk:"ssh_known_hosts"`
+	ID                 types.String `tfsdk:"id"`
+	Repository         types.String `tfsdk:"repository"`
+	GitURL             types.String `tfsdk:"git_url"`
+	GitHookTTL         types.Int32  `tfsdk:"git_hook_ttl"`
+	GitRef             types.String `tfsdk:"git_ref"`
+	PathPrefix         types.String `tfsdk:"path_prefix"`
+	ResolvedCommit     types.String `tfsdk:"resolved_commit"`
+	BasicAuth          types.Object `tfsdk:"basic_auth"`
+	SSHAuth            types.Object `tfsdk:"ssh_auth"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// runbookBasicAuthModel maps the basic_auth attribute of the runbook configuration resource.
+type runbookBasicAuthModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+}
+
+// runbookSSHAuthModel maps the ssh_auth attribute of the runbook configuration resource.
+type runbookSSHAuthModel struct {
+	User                 types.String `tfsdk:"user"`
+	PrivateKey           types.String `tfsdk:"private_key"`
+	PrivateKeyPassphrase types.String `tfsdk:"private_key_passphrase"`
+	KnownHosts           types.String `tfsdk:"known_hosts"`
+}
+
+var runbookBasicAuthAttrTypes = map[string]attr.Type{
+	"username": types.StringType,
+	"password": types.StringType,
+}
+
+var runbookSSHAuthAttrTypes = map[string]attr.Type{
+	"user":                   types.StringType,
+	"private_key":            types.StringType,
+	"private_key_passphrase": types.StringType,
+	"known_hosts":            types.StringType,
 }
 
 // runbookConfigurationResource is the data source implementation.
@@ -53,7 +88,15 @@ func (r *runbookConfigurationResource) Metadata(_ context.Context, req resource.
 func (r *runbookConfigurationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Runbook Configuration resources. Make sure to work with this resource only with the gateway version 1.47.0 and onwards",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the runbook configuration, it's derived from the git url.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"repository": schema.StringAttribute{
 				Description: "The normalized name of the repository. E.g.: 'github.com/hoophq/runbooks'",
 				Computed:    true,
@@ -63,35 +106,85 @@ func (r *runbookConfigurationResource) Schema(_ context.Context, _ resource.Sche
 			},
 			"git_url": schema.StringAttribute{
 				Required:    true,
-				Description: "Git repository URL where the runbook is located.",
-			},
-			"git_user": schema.StringAttribute{
-				Required:    true,
-				Description: "Git username for repository authentication.",
-			},
-			"git_password": schema.StringAttribute{
-				Required:    true,
-				Description: "Git password or token for repository authentication.",
+				Description: "Git repository URL where the runbook is located. Changing it replaces the runbook configuration.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"git_hook_ttl": schema.Int32Attribute{
-				Required:    true,
-				Description: "Git password or token for repository authentication.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int32default.StaticInt32(0),
+				Description: "The time to live, in seconds, of the git hook of the repository. Defaults to `0`.",
 			},
-			"ssh_user": schema.StringAttribute{
-				Required:    true,
-				Description: "SSH username for Git repository authentication.",
+			"git_ref": schema.StringAttribute{
+				Optional:    true,
+				Description: "The branch, tag or commit of the repository served by the gateway. Defaults to the default branch of the repository.",
+				Validators:  NonEmptyStringValidator,
 			},
-			"ssh_key": schema.StringAttribute{
-				Required:    true,
-				Description: "SSH private key for Git repository authentication.",
+			"path_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only serves the runbooks under this path of the repository, e.g.: `ops/`.",
+				Validators:  NonEmptyStringValidator,
 			},
-			"ssh_keypass": schema.StringAttribute{
-				Required:    true,
-				Description: "SSH key passphrase for encrypted SSH keys.",
+			"resolved_commit": schema.StringAttribute{
+				Computed:    true,
+				Description: "The commit of the repository the gateway is serving.",
 			},
-			"ssh_known_hosts": schema.StringAttribute{
-				Required:    true,
-				Description: "SSH known hosts for host key verification.",
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"basic_auth": schema.SingleNestedBlock{
+				Description: "Authenticates in the repository with a username and a password or token. Omit it and `ssh_auth` for public repositories. Conflicts with `ssh_auth`.",
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("ssh_auth")),
+					// the attributes of an optional block can't be required, they're null when it's omitted
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("password")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						Optional:    true,
+						Description: "Git username for repository authentication.",
+						Validators:  NonEmptyStringValidator,
+					},
+					"password": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Git password or token for repository authentication. Required in the `basic_auth` block.",
+						Validators:  NonEmptyStringValidator,
+					},
+				},
+			},
+			"ssh_auth": schema.SingleNestedBlock{
+				Description: "Authenticates in the repository with a SSH private key. Omit it and `basic_auth` for public repositories. Conflicts with `basic_auth`.",
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("basic_auth")),
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("private_key")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"user": schema.StringAttribute{
+						Optional:    true,
+						Description: "SSH username for Git repository authentication.",
+						Validators:  NonEmptyStringValidator,
+					},
+					"private_key": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "SSH private key for Git repository authentication. Required in the `ssh_auth` block.",
+						Validators:  NonEmptyStringValidator,
+					},
+					"private_key_passphrase": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "SSH key passphrase for encrypted SSH keys.",
+						Validators:  NonEmptyStringValidator,
+					},
+					"known_hosts": schema.StringAttribute{
+						Optional:    true,
+						Description: "SSH known hosts for host key verification.",
+						Validators:  NonEmptyStringValidator,
+					},
+				},
 			},
 		},
 	}
@@ -107,24 +200,33 @@ func (r *runbookConfigurationResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	repo, err := r.client.GetRunbookConfigByURL(currentState.GitURL.ValueString())
+	// imported resources only have the id set, it could be the git url or the repository name
+	key := currentState.GitURL.ValueString()
+	if currentState.GitURL.IsNull() {
+		key = currentState.ID.ValueString()
+	}
+	repo, err := r.client.GetRunbookRepo(key)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Runbook Repository",
-			fmt.Sprintf("Failed reading runbooks repository for %v, err=%v", currentState.GitURL.ValueString(), err),
+			fmt.Sprintf("Failed reading runbooks repository for %v, err=%v", key, err),
 		)
 		return
 	}
 
+	currentState.ID = types.StringValue(hoop.RunbookRepoID(repo.GitURL))
 	currentState.GitURL = types.StringValue(repo.GitURL)
-	currentState.GitUser = types.StringValue(repo.GitUser)
-	currentState.GitPassword = types.StringValue(repo.GitPassword)
 	currentState.GitHookTTL = types.Int32Value(repo.GitHookTTL)
-	currentState.SSHUser = types.StringValue(repo.SSHUser)
-	currentState.SSHKey = types.StringValue(repo.SSHKey)
-	currentState.SSHKeyPass = types.StringValue(repo.SSHKeyPass)
-	currentState.SSHKnownHosts = types.StringValue(repo.SSHKnownHosts)
+	currentState.GitRef = stringValueOrNull(repo.GitRef)
+	currentState.PathPrefix = stringValueOrNull(repo.PathPrefix)
+	currentState.ResolvedCommit = stringValueOrNull(repo.ResolvedCommit)
 	currentState.Repository = types.StringValue(repo.Repository)
+	currentState.BasicAuth, currentState.SSHAuth, diags = fromApiRunbookRepoAuth(*repo)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	currentState.DeletionProtection = deletionProtectionValue(currentState.DeletionProtection)
 	diags = resp.State.Set(ctx, currentState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -141,16 +243,13 @@ func (r *runbookConfigurationResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	repo, err := r.client.CreateRunbookRepo(hoop.RunbookRepo{
-		GitURL:        plan.GitURL.ValueString(),
-		GitUser:       plan.GitUser.ValueString(),
-		GitPassword:   plan.GitPassword.ValueString(),
-		GitHookTTL:    plan.GitHookTTL.ValueInt32(),
-		SSHUser:       plan.SSHUser.ValueString(),
-		SSHKey:        plan.SSHKey.ValueString(),
-		SSHKeyPass:    plan.SSHKeyPass.ValueString(),
-		SSHKnownHosts: plan.SSHKnownHosts.ValueString(),
-	})
+	requestRepo, diags := toApiRunbookRepo(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repo, err := r.client.CreateRunbookRepo(requestRepo)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Runbook Repository",
@@ -159,7 +258,9 @@ func (r *runbookConfigurationResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	plan.ID = types.StringValue(hoop.RunbookRepoID(repo.GitURL))
 	plan.Repository = types.StringValue(repo.Repository)
+	plan.ResolvedCommit = stringValueOrNull(repo.ResolvedCommit)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, &plan)
@@ -178,16 +279,13 @@ func (r *runbookConfigurationResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	repo, err := r.client.UpdateRunbookRepoByID(hoop.RunbookRepo{
-		GitURL:        plan.GitURL.ValueString(),
-		GitUser:       plan.GitUser.ValueString(),
-		GitPassword:   plan.GitPassword.ValueString(),
-		GitHookTTL:    plan.GitHookTTL.ValueInt32(),
-		SSHUser:       plan.SSHUser.ValueString(),
-		SSHKey:        plan.SSHKey.ValueString(),
-		SSHKeyPass:    plan.SSHKeyPass.ValueString(),
-		SSHKnownHosts: plan.SSHKnownHosts.ValueString(),
-	})
+	requestRepo, diags := toApiRunbookRepo(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repo, err := r.client.UpdateRunbookRepoByID(requestRepo)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -198,6 +296,7 @@ func (r *runbookConfigurationResource) Update(ctx context.Context, req resource.
 	}
 
 	plan.Repository = types.StringValue(repo.Repository)
+	plan.ResolvedCommit = stringValueOrNull(repo.ResolvedCommit)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -215,6 +314,14 @@ func (r *runbookConfigurationResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Error Deleting Runbook Configuration",
+			deletionProtectionErrorDetail(fmt.Sprintf("runbook configuration for %q", state.GitURL.ValueString())),
+		)
+		return
+	}
+
 	if err := r.client.DeleteRunbookRepoByID(state.GitURL.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Runbook Configuration",
@@ -224,8 +331,14 @@ func (r *runbookConfigurationResource) Delete(ctx context.Context, req resource.
 	}
 }
 
+// ModifyPlan warns when a configuration protected against deletion is planned to be replaced.
+func (r *runbookConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnDeletionProtectionReplace(ctx, req, resp, path.Root("git_url"))
+}
+
+// ImportState imports a runbook configuration by its id, git url or normalized repository name.
 func (r *runbookConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("git_url"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
 // Configure adds the provider configured client to the data source.
@@ -236,14 +349,79 @@ func (r *runbookConfigurationResource) Configure(_ context.Context, req resource
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+}
+
+// toApiRunbookRepo converts the resource model to the runbook repository of the API,
+// the credentials of the authentication method that is not set are sent empty.
+func toApiRunbookRepo(ctx context.Context, obj runbookConfigurationResourceModel) (repo hoop.RunbookRepo, diags diag.Diagnostics) {
+	repo = hoop.RunbookRepo{
+		GitURL:     obj.GitURL.ValueString(),
+		GitHookTTL: obj.GitHookTTL.ValueInt32(),
+		GitRef:     obj.GitRef.ValueString(),
+		PathPrefix: obj.PathPrefix.ValueString(),
+	}
+	if !obj.BasicAuth.IsNull() && !obj.BasicAuth.IsUnknown() {
+		var basicAuth runbookBasicAuthModel
+		diags = obj.BasicAuth.As(ctx, &basicAuth, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			return
+		}
+		repo.GitUser = basicAuth.Username.ValueString()
+		repo.GitPassword = basicAuth.Password.ValueString()
+	}
+	if !obj.SSHAuth.IsNull() && !obj.SSHAuth.IsUnknown() {
+		var sshAuth runbookSSHAuthModel
+		diags = obj.SSHAuth.As(ctx, &sshAuth, basetypes.ObjectAsOptions{})
+		if diags.HasError() {
+			return
+		}
+		repo.SSHUser = sshAuth.User.ValueString()
+		repo.SSHKey = sshAuth.PrivateKey.ValueString()
+		repo.SSHKeyPass = sshAuth.PrivateKeyPassphrase.ValueString()
+		repo.SSHKnownHosts = sshAuth.KnownHosts.ValueString()
+	}
+	return
+}
+
+// fromApiRunbookRepoAuth converts the credentials of a runbook repository to the basic_auth
+// and ssh_auth attributes. An authentication method without credentials is set to null.
+func fromApiRunbookRepoAuth(repo hoop.RunbookRepo) (basicAuth, sshAuth types.Object, diags diag.Diagnostics) {
+	basicAuth = types.ObjectNull(runbookBasicAuthAttrTypes)
+	sshAuth = types.ObjectNull(runbookSSHAuthAttrTypes)
+	if repo.GitPassword != "" {
+		basicAuth, diags = types.ObjectValue(runbookBasicAuthAttrTypes, map[string]attr.Value{
+			"username": stringValueOrNull(repo.GitUser),
+			"password": types.StringValue(repo.GitPassword),
+		})
+		if diags.HasError() {
+			return
+		}
+	}
+	if repo.SSHKey != "" {
+		sshAuth, diags = types.ObjectValue(runbookSSHAuthAttrTypes, map[string]attr.Value{
+			"user":                   stringValueOrNull(repo.SSHUser),
+			"private_key":            types.StringValue(repo.SSHKey),
+			"private_key_passphrase": stringValueOrNull(repo.SSHKeyPass),
+			"known_hosts":            stringValueOrNull(repo.SSHKnownHosts),
+		})
+	}
+	return
+}
+
+// stringValueOrNull coerces empty strings to a null value.
+func stringValueOrNull(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
 }
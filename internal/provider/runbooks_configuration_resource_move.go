@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+var _ resource.ResourceWithMoveState = &runbookConfigurationResource{}
+
+// pluginConfigSourceSchema is the schema of the hoop_plugin_config resource
+// used to decode the source state when moving it to a runbook configuration.
+func pluginConfigSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true},
+			"plugin_name": schema.StringAttribute{Required: true},
+			"config":      schema.MapAttribute{Required: true, Sensitive: true, ElementType: types.StringType},
+		},
+	}
+}
+
+// MoveState allows moving a hoop_plugin_config of the runbooks plugin to this resource.
+func (r *runbookConfigurationResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: pluginConfigSourceSchema(),
+			StateMover:   r.moveStateFromPluginConfig,
+		},
+	}
+}
+
+// moveStateFromPluginConfig translates the configuration map of the legacy runbooks plugin
+// into the attributes of the runbook configuration resource.
+func (r *runbookConfigurationResource) moveStateFromPluginConfig(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "hoop_plugin_config" {
+		return
+	}
+
+	var source pluginConfigResourceModel
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if source.PluginName.ValueString() != "runbooks" {
+		resp.Diagnostics.AddError(
+			"Unsupported Move State",
+			fmt.Sprintf("Only the runbooks plugin configuration can be moved to hoop_runbook_configuration, got plugin %q",
+				source.PluginName.ValueString()),
+		)
+		return
+	}
+
+	config := map[string]string{}
+	resp.Diagnostics.Append(source.Config.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gitURL := config["GIT_URL"]
+	if gitURL == "" {
+		resp.Diagnostics.AddError(
+			"Error Moving Plugin Config",
+			"The runbooks plugin configuration is missing the GIT_URL attribute",
+		)
+		return
+	}
+
+	// the normalized repository name and the hook ttl are only known by the gateway,
+	// they are refreshed when reading the resource after the move.
+	state := runbookConfigurationResourceModel{
+		ID:                 types.StringValue(hoop.RunbookRepoID(gitURL)),
+		Repository:         types.StringNull(),
+		GitURL:             types.StringValue(gitURL),
+		GitHookTTL:         types.Int32Value(0),
+		GitRef:             types.StringNull(),
+		PathPrefix:         types.StringNull(),
+		ResolvedCommit:     types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
+	}
+	var diags diag.Diagnostics
+	state.BasicAuth, state.SSHAuth, diags = fromApiRunbookRepoAuth(hoop.RunbookRepo{
+		GitUser:       config["GIT_USER"],
+		GitPassword:   config["GIT_PASSWORD"],
+		SSHUser:       config["GIT_SSH_USER"],
+		SSHKey:        config["GIT_SSH_KEY"],
+		SSHKeyPass:    config["GIT_SSH_KEYPASS"],
+		SSHKnownHosts: config["GIT_SSH_KNOWN_HOSTS"],
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, state)...)
+}
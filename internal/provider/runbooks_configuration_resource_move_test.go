@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func TestRunbookConfigurationResourceMoveStateFromPluginConfig(t *testing.T) {
+	var state runbookConfigurationResourceModel
+	moveTestState(t, "hoop_plugin_config", NewRunbookConfigurationResource, `{
+  "id": "runbooks",
+  "plugin_name": "runbooks",
+  "config": {
+    "GIT_URL": "git@github.com:your-org/your-repo.git",
+    "GIT_SSH_KEY": "ssh-private-key",
+    "GIT_SSH_USER": "git"
+  }
+}`, &state)
+
+	if !state.Repository.IsNull() {
+		t.Errorf("expected repository to be refreshed by read, got %v", state.Repository)
+	}
+	if state.GitURL.ValueString() != "git@github.com:your-org/your-repo.git" || state.GitHookTTL.ValueInt32() != 0 {
+		t.Errorf("expected git_url and git_hook_ttl to be set, got %v, %v", state.GitURL, state.GitHookTTL)
+	}
+	if state.ID.ValueString() != hoop.RunbookRepoID("git@github.com:your-org/your-repo.git") {
+		t.Errorf("expected id to be derived from the git url, got %v", state.ID)
+	}
+	if !state.BasicAuth.IsNull() {
+		t.Errorf("expected basic_auth to be null without a git password, got %v", state.BasicAuth)
+	}
+	var sshAuth runbookSSHAuthModel
+	if diags := state.SSHAuth.As(context.Background(), &sshAuth, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed decoding ssh_auth: %v", diags)
+	}
+	if sshAuth.PrivateKey.ValueString() != "ssh-private-key" || sshAuth.User.ValueString() != "git" {
+		t.Errorf("expected ssh attributes to be translated, got private_key=%v, user=%v", sshAuth.PrivateKey, sshAuth.User)
+	}
+	if !sshAuth.PrivateKeyPassphrase.IsNull() || !sshAuth.KnownHosts.IsNull() {
+		t.Errorf("expected missing attributes to be null, got private_key_passphrase=%v, known_hosts=%v",
+			sshAuth.PrivateKeyPassphrase, sshAuth.KnownHosts)
+	}
+}
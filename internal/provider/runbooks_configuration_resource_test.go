@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"crypto/sha1"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -10,10 +13,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
 )
 
 func createFakeRunbookConfigurationTestServer() clientFunc {
+	return createFakeRunbookConfigurationTestServerWithCommits(fakeResolvedCommit)
+}
+
+// createFakeRunbookConfigurationTestServerWithCommits creates a fake server serving the
+// revisions of the repositories with the commits returned by resolveCommit
+func createFakeRunbookConfigurationTestServerWithCommits(resolveCommit func(gitURL, gitRef string) string) clientFunc {
 	store := map[string]*hoop.RunbookRepo{}
 	return clientFunc(func(req *http.Request) (*http.Response, error) {
 		switch req.Method {
@@ -28,6 +39,7 @@ func createFakeRunbookConfigurationTestServer() clientFunc {
 				return httpTestErr(http.StatusConflict, `runbook configuration with git url %q already exists`, resource.GitURL), nil
 			}
 			resource.Repository = "fake-git-url-normalization" // no need to check this value for this tests
+			resource.ResolvedCommit = resolveCommit(resource.GitURL, resource.GitRef)
 			store[resourceID] = &resource
 			return httpTestOk(http.StatusCreated, &resource), nil
 		// GET /api/runbooks/configurations endpoint
@@ -58,6 +70,7 @@ func createFakeRunbookConfigurationTestServer() clientFunc {
 				}
 			}
 			resource.Repository = "fake-git-url-normalization" // no need to check this value for this tests
+			resource.ResolvedCommit = resolveCommit(resource.GitURL, resource.GitRef)
 			store[resourceID] = &resource
 			return httpTestOk(http.StatusOK, resource), nil
 		case http.MethodDelete:
@@ -74,6 +87,14 @@ func createFakeRunbookConfigurationTestServer() clientFunc {
 	})
 }
 
+// fakeResolvedCommit returns a deterministic commit for the revision of the repository
+func fakeResolvedCommit(gitURL, gitRef string) string {
+	if gitRef == "" {
+		gitRef = "main"
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(gitURL+"@"+gitRef)))
+}
+
 func TestRunbooksConfigurationResource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		IsUnitTest: true,
@@ -90,24 +111,32 @@ provider "hoop" {
 }
 
 resource "hoop_runbook_configuration" "repo" {
-  git_url         = "https://github.com/hoophq/runbooks.git"
-  git_hook_ttl    = 122
-  git_user        = "gituser"
-  git_password    = "gitpwd"
-  ssh_user        = "sshuser"
-  ssh_key         = "sshkey"
-  ssh_keypass     = "sshkeypass"
-  ssh_known_hosts = "ssh-known-hosts-file"
+  git_url      = "git@github.com:hoophq/runbooks.git"
+  git_hook_ttl = 122
+  git_ref      = "v1.2.0"
+  path_prefix  = "ops/"
+
+  ssh_auth {
+    user                   = "sshuser"
+    private_key            = "sshkey"
+    private_key_passphrase = "sshkeypass"
+    known_hosts            = "ssh-known-hosts-file"
+  }
 }`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_url", "https://github.com/hoophq/runbooks.git"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_url", "git@github.com:hoophq/runbooks.git"),
 					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_hook_ttl", "122"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_user", "gituser"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_password", "gitpwd"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_user", "sshuser"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_key", "sshkey"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_keypass", "sshkeypass"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_known_hosts", "ssh-known-hosts-file"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_ref", "v1.2.0"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "path_prefix", "ops/"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "resolved_commit",
+						fakeResolvedCommit("git@github.com:hoophq/runbooks.git", "v1.2.0")),
+					resource.TestCheckNoResourceAttr("hoop_runbook_configuration.repo", "basic_auth"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_auth.user", "sshuser"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_auth.private_key", "sshkey"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_auth.private_key_passphrase", "sshkeypass"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_auth.known_hosts", "ssh-known-hosts-file"),
+
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "id", hoop.RunbookRepoID("git@github.com:hoophq/runbooks.git")),
 
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hoop_runbook_configuration.repo", "repository"),
@@ -122,25 +151,24 @@ provider "hoop" {
 }
 
 resource "hoop_runbook_configuration" "repo" {
-  git_url         = "https://github.com/hoophq/runbooks.git"
-  git_hook_ttl    = 0
-  git_user        = "gituser"
-  git_password    = "gitpwd"
-  ssh_user        = ""
-  ssh_key         = ""
-  ssh_keypass     = ""
-  ssh_known_hosts = ""
+  git_url = "git@github.com:hoophq/runbooks.git"
+
+  basic_auth {
+    username = "gituser"
+    password = "gitpwd"
+  }
 }
 						`,
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_url", "https://github.com/hoophq/runbooks.git"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_url", "git@github.com:hoophq/runbooks.git"),
 					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_hook_ttl", "0"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_user", "gituser"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_password", "gitpwd"),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_user", ""),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_key", ""),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_keypass", ""),
-					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "ssh_known_hosts", ""),
+					resource.TestCheckNoResourceAttr("hoop_runbook_configuration.repo", "git_ref"),
+					resource.TestCheckNoResourceAttr("hoop_runbook_configuration.repo", "path_prefix"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "resolved_commit",
+						fakeResolvedCommit("git@github.com:hoophq/runbooks.git", "")),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "basic_auth.username", "gituser"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "basic_auth.password", "gitpwd"),
+					resource.TestCheckNoResourceAttr("hoop_runbook_configuration.repo", "ssh_auth"),
 
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hoop_runbook_configuration.repo", "repository"),
@@ -151,8 +179,200 @@ resource "hoop_runbook_configuration" "repo" {
 				ResourceName:                         "hoop_runbook_configuration.repo",
 				ImportState:                          true,
 				ImportStateVerify:                    true,
-				ImportStateVerifyIdentifierAttribute: "git_url",
-				ImportStateId:                        "https://github.com/hoophq/runbooks.git",
+				ImportStateVerifyIdentifierAttribute: "id",
+				ImportStateId:                        "git@github.com:hoophq/runbooks.git",
+			},
+			// ImportState by the repository name
+			{
+				ResourceName:                         "hoop_runbook_configuration.repo",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "id",
+				ImportStateId:                        "fake-git-url-normalization",
+			},
+			// Changing the git url replaces the configuration
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_configuration" "repo" {
+  git_url = "https://github.com/hoophq/runbooks.git"
+}
+						`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hoop_runbook_configuration.repo", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_url", "https://github.com/hoophq/runbooks.git"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "id", hoop.RunbookRepoID("https://github.com/hoophq/runbooks.git")),
+				),
+			},
+		},
+	})
+}
+
+func TestRunbooksConfigurationResourceBranchMoved(t *testing.T) {
+	// the branch moves after the configuration is created, every following
+	// write of the configuration serves a new commit of the same branch
+	pushes := 0
+	resolveCommit := func(gitURL, gitRef string) string {
+		pushes++
+		return fakeResolvedCommit(gitURL, fmt.Sprintf("%s~%d", gitRef, pushes))
+	}
+	resourceConfig := func(gitHookTTL int) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_configuration" "repo" {
+  git_url      = "https://github.com/hoophq/runbooks.git"
+  git_ref      = "main"
+  git_hook_ttl = %v
+}`, gitHookTTL)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeRunbookConfigurationTestServerWithCommits(resolveCommit))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: resourceConfig(60),
+				Check: resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "resolved_commit",
+					fakeResolvedCommit("https://github.com/hoophq/runbooks.git", "main~1")),
+			},
+			// updating an unrelated attribute serves the commit the branch moved to
+			{
+				Config: resourceConfig(120),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hoop_runbook_configuration.repo", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("hoop_runbook_configuration.repo", tfjsonpath.New("resolved_commit")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "git_hook_ttl", "120"),
+					resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "resolved_commit",
+						fakeResolvedCommit("https://github.com/hoophq/runbooks.git", "main~2")),
+				),
+			},
+		},
+	})
+}
+
+func TestRunbooksConfigurationResourceDeletionProtection(t *testing.T) {
+	resourceConfig := func(deletionProtection bool) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_configuration" "repo" {
+  git_url = "https://github.com/hoophq/runbooks.git"
+
+  deletion_protection = %v
+}`, deletionProtection)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeRunbookConfigurationTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: resourceConfig(true),
+				Check:  resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "deletion_protection", "true"),
+			},
+			{
+				Config:      resourceConfig(true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`deletion_protection enabled`),
+			},
+			{
+				Config: resourceConfig(false),
+				Check:  resource.TestCheckResourceAttr("hoop_runbook_configuration.repo", "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
+func TestRunbooksConfigurationResourceDeletionProtectionReplace(t *testing.T) {
+	state := func(gitURL, gitRef string) string {
+		return fmt.Sprintf(`{
+  "id": "8d3b2f3e-0c9d-4b4e-9a43-6f1f1c1d2a10",
+  "repository": "github.com/hoophq/runbooks",
+  "git_url": %q,
+  "git_hook_ttl": null,
+  "git_ref": %q,
+  "path_prefix": null,
+  "resolved_commit": null,
+  "basic_auth": null,
+  "ssh_auth": null,
+  "deletion_protection": true
+}`, gitURL, gitRef)
+	}
+	priorState := state("https://github.com/hoophq/runbooks.git", "main")
+
+	for _, tt := range []struct {
+		name             string
+		proposedNewState string
+		wantWarning      bool
+	}{
+		{"replace git_url", state("https://github.com/hoophq/other-runbooks.git", "main"), true},
+		{"update git_ref", state("https://github.com/hoophq/runbooks.git", "develop"), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := planTestResourceChange(t, NewRunbookConfigurationResource, priorState, tt.proposedNewState)
+			if hasWarning := hasTestDiagnostic(resp.Diagnostics, tfprotov6.DiagnosticSeverityWarning, "Resource Replacement Blocked by Deletion Protection"); hasWarning != tt.wantWarning {
+				t.Errorf("expected deletion protection warning to be %v, got diagnostics %v", tt.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestRunbooksConfigurationResourceAuthValidation(t *testing.T) {
+	resourceConfig := func(auth string) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_configuration" "repo" {
+  git_url = "git@github.com:hoophq/runbooks.git"
+%s
+}`, auth)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeRunbookConfigurationTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      resourceConfig(`basic_auth {}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"basic_auth.password" must be specified`),
+			},
+			{
+				Config:      resourceConfig(`ssh_auth { user = "git" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"ssh_auth.private_key" must be specified`),
+			},
+			{
+				Config: resourceConfig(`
+  basic_auth { password = "gitpwd" }
+  ssh_auth { private_key = "sshkey" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`cannot be specified when`),
 			},
 		},
 	})
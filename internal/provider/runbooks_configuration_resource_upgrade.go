@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// runbookConfigurationResourceModelV0 maps the schema version 0 of the runbook configuration
+// resource, where the credentials of both authentication methods were flat attributes.
+type runbookConfigurationResourceModelV0 struct {
+	Repository    types.String `tfsdk:"repository"`
+	GitURL        types.String `tfsdk:"git_url"`
+	GitUser       types.String `tfsdk:"git_user"`
+	GitPassword   types.String `tfsdk:"git_password"`
+	GitHookTTL    types.Int32  `tfsdk:"git_hook_ttl"`
+	SSHUser       types.String `tfsdk:"ssh_user"`
+	SSHKey        types.String `tfsdk:"ssh_key"`
+	SSHKeyPass    types.String `tfsdk:"ssh_keypass"`
+	SSHKnownHosts types.String `tfsdk:"ssh_known_hosts"`
+}
+
+// runbookConfigurationSchemaV0 is the schema version 0 of the runbook configuration resource.
+// It must not be changed, it's only used to decode prior states.
+func runbookConfigurationSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"repository":      schema.StringAttribute{Computed: true},
+			"git_url":         schema.StringAttribute{Required: true},
+			"git_user":        schema.StringAttribute{Required: true},
+			"git_password":    schema.StringAttribute{Required: true},
+			"git_hook_ttl":    schema.Int32Attribute{Required: true},
+			"ssh_user":        schema.StringAttribute{Required: true},
+			"ssh_key":         schema.StringAttribute{Required: true},
+			"ssh_keypass":     schema.StringAttribute{Required: true},
+			"ssh_known_hosts": schema.StringAttribute{Required: true},
+		},
+	}
+}
+
+// UpgradeState upgrades prior states of the runbook configuration resource to the current schema version.
+func (r *runbookConfigurationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: runbookConfigurationSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState runbookConfigurationResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState, diags := upgradeRunbookConfigurationStateV0(priorState)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+	}
+}
+
+// upgradeRunbookConfigurationStateV0 moves the flat credential attributes of the
+// schema version 0 to the basic_auth and ssh_auth attributes and sets the
+// attributes added after the schema version 0 to their default values.
+func upgradeRunbookConfigurationStateV0(prior runbookConfigurationResourceModelV0) (state runbookConfigurationResourceModel, diags diag.Diagnostics) {
+	state = runbookConfigurationResourceModel{
+		ID:                 types.StringValue(hoop.RunbookRepoID(prior.GitURL.ValueString())),
+		Repository:         prior.Repository,
+		GitURL:             prior.GitURL,
+		GitHookTTL:         prior.GitHookTTL,
+		GitRef:             types.StringNull(),
+		PathPrefix:         types.StringNull(),
+		ResolvedCommit:     types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
+	}
+	state.BasicAuth, state.SSHAuth, diags = fromApiRunbookRepoAuth(hoop.RunbookRepo{
+		GitUser:       prior.GitUser.ValueString(),
+		GitPassword:   prior.GitPassword.ValueString(),
+		SSHUser:       prior.SSHUser.ValueString(),
+		SSHKey:        prior.SSHKey.ValueString(),
+		SSHKeyPass:    prior.SSHKeyPass.ValueString(),
+		SSHKnownHosts: prior.SSHKnownHosts.ValueString(),
+	})
+	return
+}
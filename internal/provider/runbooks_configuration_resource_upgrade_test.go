@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func TestRunbookConfigurationResourceUpgradeStateV0(t *testing.T) {
+	var state runbookConfigurationResourceModel
+	upgradeTestState(t, NewRunbookConfigurationResource, 0, `{
+  "repository": "github.com/hoophq/runbooks",
+  "git_url": "https://github.com/hoophq/runbooks.git",
+  "git_user": "gituser",
+  "git_password": "gitpwd",
+  "git_hook_ttl": 122,
+  "ssh_user": "",
+  "ssh_key": "",
+  "ssh_keypass": "",
+  "ssh_known_hosts": ""
+}`, &state)
+
+	if state.Repository.ValueString() != "github.com/hoophq/runbooks" || state.GitHookTTL.ValueInt32() != 122 {
+		t.Errorf("expected attributes to be preserved, got repository=%v, git_hook_ttl=%v", state.Repository, state.GitHookTTL)
+	}
+	if state.ID.ValueString() != hoop.RunbookRepoID("https://github.com/hoophq/runbooks.git") {
+		t.Errorf("expected id to be derived from the git url, got %v", state.ID)
+	}
+	if state.DeletionProtection.IsNull() || state.DeletionProtection.ValueBool() {
+		t.Errorf("expected deletion_protection to default to false, got %v", state.DeletionProtection)
+	}
+	if !state.SSHAuth.IsNull() {
+		t.Errorf("expected ssh_auth to be null without a ssh key, got %v", state.SSHAuth)
+	}
+	var basicAuth runbookBasicAuthModel
+	if diags := state.BasicAuth.As(context.Background(), &basicAuth, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("failed decoding basic_auth: %v", diags)
+	}
+	if basicAuth.Username.ValueString() != "gituser" || basicAuth.Password.ValueString() != "gitpwd" {
+		t.Errorf("expected basic_auth to be migrated, got username=%v, password=%v", basicAuth.Username, basicAuth.Password)
+	}
+}
+
+func TestRunbookConfigurationResourceUpgradeStateV0PublicRepository(t *testing.T) {
+	var state runbookConfigurationResourceModel
+	upgradeTestState(t, NewRunbookConfigurationResource, 0, `{
+  "repository": "github.com/hoophq/runbooks",
+  "git_url": "https://github.com/hoophq/runbooks.git",
+  "git_user": "",
+  "git_password": "",
+  "git_hook_ttl": 0,
+  "ssh_user": "",
+  "ssh_key": "",
+  "ssh_keypass": "",
+  "ssh_known_hosts": ""
+}`, &state)
+
+	if !state.BasicAuth.IsNull() || !state.SSHAuth.IsNull() {
+		t.Errorf("expected both authentication methods to be null, got basic_auth=%v, ssh_auth=%v", state.BasicAuth, state.SSHAuth)
+	}
+	if !state.GitRef.IsNull() || !state.PathPrefix.IsNull() || !state.ResolvedCommit.IsNull() {
+		t.Errorf("expected git_ref, path_prefix and resolved_commit to be null, got %v, %v, %v",
+			state.GitRef, state.PathPrefix, state.ResolvedCommit)
+	}
+}
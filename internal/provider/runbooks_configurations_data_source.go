@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+const (
+	runbookAuthTypeNone  = "none"
+	runbookAuthTypeBasic = "basic"
+	runbookAuthTypeSSH   = "ssh"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &runbookConfigurationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &runbookConfigurationsDataSource{}
+)
+
+// NewRunbookConfigurationsDataSource is a helper function to simplify the provider implementation.
+func NewRunbookConfigurationsDataSource() datasource.DataSource {
+	return &runbookConfigurationsDataSource{}
+}
+
+// runbookConfigurationsDataSourceModel maps the data source schema data.
+type runbookConfigurationsDataSourceModel struct {
+	Repositories types.List `tfsdk:"repositories"`
+}
+
+var runbookConfigurationAttrTypes = map[string]attr.Type{
+	"id":              types.StringType,
+	"repository":      types.StringType,
+	"git_url":         types.StringType,
+	"git_hook_ttl":    types.Int32Type,
+	"git_ref":         types.StringType,
+	"path_prefix":     types.StringType,
+	"resolved_commit": types.StringType,
+	"auth_type":       types.StringType,
+}
+
+// runbookConfigurationsDataSource is the data source implementation.
+type runbookConfigurationsDataSource struct {
+	client *hoop.Client
+}
+
+// Metadata returns the data source type name.
+func (d *runbookConfigurationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runbook_configurations"
+}
+
+// Schema defines the schema for the data source.
+func (d *runbookConfigurationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the repositories with a runbook configuration. The credentials of the repositories are not exposed.",
+		Attributes: map[string]schema.Attribute{
+			"repositories": schema.ListNestedAttribute{
+				Description: "The repositories with a runbook configuration, sorted by the repository name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The unique identifier of the runbook configuration, it's derived from the git url.",
+							Computed:    true,
+						},
+						"repository": schema.StringAttribute{
+							Description: "The normalized name of the repository. E.g.: 'github.com/hoophq/runbooks'",
+							Computed:    true,
+						},
+						"git_url": schema.StringAttribute{
+							Description: "Git repository URL where the runbook is located.",
+							Computed:    true,
+						},
+						"git_hook_ttl": schema.Int32Attribute{
+							Description: "The time to live, in seconds, of the git hook of the repository.",
+							Computed:    true,
+						},
+						"git_ref": schema.StringAttribute{
+							Description: "The branch, tag or commit of the repository served by the gateway.",
+							Computed:    true,
+						},
+						"path_prefix": schema.StringAttribute{
+							Description: "The path of the repository the runbooks are served from.",
+							Computed:    true,
+						},
+						"resolved_commit": schema.StringAttribute{
+							Description: "The commit of the repository the gateway is serving.",
+							Computed:    true,
+						},
+						"auth_type": schema.StringAttribute{
+							Description: fmt.Sprintf("How the gateway authenticates in the repository: `%s`, `%s` or `%s`.",
+								runbookAuthTypeNone, runbookAuthTypeBasic, runbookAuthTypeSSH),
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *runbookConfigurationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state runbookConfigurationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repos, err := d.client.ListRunbookRepos()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Runbook Configurations",
+			fmt.Sprintf("failed listing runbook configurations, reason=%v", err),
+		)
+		return
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Repository < repos[j].Repository })
+
+	var diags diag.Diagnostics
+	state.Repositories, diags = fromApiRunbookConfigurationList(repos)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *runbookConfigurationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hoop.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// fromApiRunbookConfigurationList converts the runbook repositories to a list of objects without their credentials.
+func fromApiRunbookConfigurationList(repos []hoop.RunbookRepo) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	objectType := types.ObjectType{AttrTypes: runbookConfigurationAttrTypes}
+	elements := []attr.Value{}
+	for _, repo := range repos {
+		authType := runbookAuthTypeNone
+		switch {
+		case repo.SSHKey != "":
+			authType = runbookAuthTypeSSH
+		case repo.GitPassword != "":
+			authType = runbookAuthTypeBasic
+		}
+		objectValue, d := types.ObjectValue(runbookConfigurationAttrTypes, map[string]attr.Value{
+			"id":              types.StringValue(hoop.RunbookRepoID(repo.GitURL)),
+			"repository":      types.StringValue(repo.Repository),
+			"git_url":         types.StringValue(repo.GitURL),
+			"git_hook_ttl":    types.Int32Value(repo.GitHookTTL),
+			"git_ref":         stringValueOrNull(repo.GitRef),
+			"path_prefix":     stringValueOrNull(repo.PathPrefix),
+			"resolved_commit": stringValueOrNull(repo.ResolvedCommit),
+			"auth_type":       types.StringValue(authType),
+		})
+		diags.Append(d...)
+		elements = append(elements, objectValue)
+	}
+	if diags.HasError() {
+		return types.ListNull(objectType), diags
+	}
+	listValue, d := types.ListValue(objectType, elements)
+	diags.Append(d...)
+	return listValue, diags
+}
@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func createFakeRunbookConfigurationsTestServer() clientFunc {
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		// GET /api/runbooks/configurations endpoint
+		if req.Method == http.MethodGet && req.URL.Path == "/api/runbooks/configurations" {
+			return httpTestOk(http.StatusOK, hoop.RunbookConfig{
+				ID: "0d5a3c2e-8f0b-4b7e-9c6a-2f1e3d4c5b6a",
+				Repositories: []hoop.RunbookRepo{
+					{
+						Repository:  "github.com/your-org/your-repo",
+						GitURL:      "https://github.com/your-org/your-repo",
+						GitUser:     "oauth2",
+						GitPassword: "your-personal-access-token",
+						GitHookTTL:  300,
+						GitRef:      "main",
+					},
+					{
+						Repository:     "github.com/hoophq/runbooks",
+						GitURL:         "https://github.com/hoophq/runbooks.git",
+						ResolvedCommit: "9a4c1f2b7e",
+					},
+					{
+						Repository: "github.com/your-org/ops",
+						GitURL:     "git@github.com:your-org/ops.git",
+						SSHKey:     "ssh-private-key",
+						PathPrefix: "runbooks/",
+					},
+				},
+			}), nil
+		}
+		return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+	})
+}
+
+func TestRunbookConfigurationsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeRunbookConfigurationsTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_runbook_configurations" "all" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.#", "3"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.0.repository", "github.com/hoophq/runbooks"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.0.id", hoop.RunbookRepoID("https://github.com/hoophq/runbooks.git")),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.0.auth_type", "none"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.0.resolved_commit", "9a4c1f2b7e"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.1.repository", "github.com/your-org/ops"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.1.auth_type", "ssh"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.1.path_prefix", "runbooks/"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.2.repository", "github.com/your-org/your-repo"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.2.auth_type", "basic"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.2.git_hook_ttl", "300"),
+					resource.TestCheckResourceAttr("data.hoop_runbook_configurations.all", "repositories.2.git_ref", "main"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,245 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &runbooksDataSource{}
+	_ datasource.DataSourceWithConfigure = &runbooksDataSource{}
+)
+
+// NewRunbooksDataSource is a helper function to simplify the provider implementation.
+func NewRunbooksDataSource() datasource.DataSource {
+	return &runbooksDataSource{}
+}
+
+// runbooksDataSourceModel maps the data source schema data.
+type runbooksDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Repository types.String `tfsdk:"repository"`
+	Glob       types.String `tfsdk:"glob"`
+	Commit     types.String `tfsdk:"commit"`
+	Runbooks   types.List   `tfsdk:"runbooks"`
+}
+
+var runbookParameterAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"description": types.StringType,
+	"required":    types.BoolType,
+	"default":     types.StringType,
+	"options":     types.ListType{ElemType: types.StringType},
+}
+
+var runbookAttrTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"repository": types.StringType,
+	"parameters": types.MapType{ElemType: types.ObjectType{AttrTypes: runbookParameterAttrTypes}},
+}
+
+// runbooksDataSource is the data source implementation.
+type runbooksDataSource struct {
+	client *hoop.Client
+}
+
+// Metadata returns the data source type name.
+func (d *runbooksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_runbooks"
+}
+
+// Schema defines the schema for the data source.
+func (d *runbooksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the runbooks of a repository configured with the `hoop_runbook_configuration` resource. " +
+			"The `runbooks` attribute could be assigned to the `runbooks` attribute of the `hoop_runbook_rule` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The normalized name of the repository.",
+				Computed:    true,
+			},
+			"repository": schema.StringAttribute{
+				Description: "The normalized name of the repository. E.g.: 'github.com/hoophq/runbooks'",
+				Required:    true,
+				Validators:  NonEmptyStringValidator,
+			},
+			"glob": schema.StringAttribute{
+				Description: "Only lists the runbooks whose relative git path matches the glob pattern, e.g.: `postgres-demo/*.runbook.sql`. " +
+					"The `*` wildcard doesn't match the path separator `/`.",
+				Optional:   true,
+				Validators: NonEmptyStringValidator,
+			},
+			"commit": schema.StringAttribute{
+				Description: "The commit of the repository the runbooks were listed from.",
+				Computed:    true,
+			},
+			"runbooks": schema.ListNestedAttribute{
+				Description: "The runbooks of the repository, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The relative git path of the runbook file.",
+							Computed:    true,
+						},
+						"repository": schema.StringAttribute{
+							Description: "The normalized name of the repository. E.g.: 'github.com/hoophq/runbooks'",
+							Computed:    true,
+						},
+						"parameters": schema.MapNestedAttribute{
+							Description: "The parameters declared by the runbook, keyed by their name.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Description: "The type of the parameter, e.g.: `text`, `number`, `email`, `select`.",
+										Computed:    true,
+									},
+									"description": schema.StringAttribute{
+										Description: "The description of the parameter.",
+										Computed:    true,
+									},
+									"required": schema.BoolAttribute{
+										Description: "Whether the parameter is required to execute the runbook.",
+										Computed:    true,
+									},
+									"default": schema.StringAttribute{
+										Description: "The default value of the parameter.",
+										Computed:    true,
+									},
+									"options": schema.ListAttribute{
+										Description: "The values allowed for `select` parameters.",
+										ElementType: types.StringType,
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *runbooksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state runbooksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	glob := state.Glob.ValueString()
+	if _, err := path.Match(glob, ""); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Glob Pattern",
+			fmt.Sprintf("The glob pattern %q is invalid: %v", glob, err),
+		)
+		return
+	}
+
+	repository := state.Repository.ValueString()
+	repo, err := d.client.ListRunbooks(repository)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Runbooks",
+			fmt.Sprintf("failed reading runbooks of repository %q, reason=%v", repository, err),
+		)
+		return
+	}
+
+	var runbooks []hoop.Runbook
+	for _, runbook := range repo.Items {
+		// the pattern is validated beforehand, the error could be ignored
+		if matched, _ := path.Match(glob, runbook.Name); glob != "" && !matched {
+			continue
+		}
+		runbooks = append(runbooks, runbook)
+	}
+	sort.Slice(runbooks, func(i, j int) bool { return runbooks[i].Name < runbooks[j].Name })
+
+	var diags diag.Diagnostics
+	state.Runbooks, diags = fromApiRunbookList(repository, runbooks)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ID = types.StringValue(repository)
+	state.Commit = stringValueOrNull(repo.Commit)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (d *runbooksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*hoop.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	d.client = client
+}
+
+// fromApiRunbookList converts the runbooks to a list of objects, the name and the
+// repository attributes match the runbooks attribute of the hoop_runbook_rule resource.
+func fromApiRunbookList(repository string, runbooks []hoop.Runbook) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	runbookType := types.ObjectType{AttrTypes: runbookAttrTypes}
+	parameterType := types.ObjectType{AttrTypes: runbookParameterAttrTypes}
+	elements := []attr.Value{}
+	for _, runbook := range runbooks {
+		parameters := map[string]attr.Value{}
+		for name, param := range runbook.Metadata {
+			options := []attr.Value{}
+			for _, opt := range param.Options {
+				options = append(options, types.StringValue(opt))
+			}
+			optionsVal, d := types.ListValue(types.StringType, options)
+			diags.Append(d...)
+			paramVal, d := types.ObjectValue(runbookParameterAttrTypes, map[string]attr.Value{
+				"type":        stringValueOrNull(param.Type),
+				"description": stringValueOrNull(param.Description),
+				"required":    types.BoolValue(param.Required),
+				"default":     stringValueOrNull(param.Default),
+				"options":     optionsVal,
+			})
+			diags.Append(d...)
+			parameters[name] = paramVal
+		}
+		parametersVal, d := types.MapValue(parameterType, parameters)
+		diags.Append(d...)
+		runbookVal, d := types.ObjectValue(runbookAttrTypes, map[string]attr.Value{
+			"name":       types.StringValue(runbook.Name),
+			"repository": types.StringValue(repository),
+			"parameters": parametersVal,
+		})
+		diags.Append(d...)
+		elements = append(elements, runbookVal)
+	}
+	if diags.HasError() {
+		return types.ListNull(runbookType), diags
+	}
+	listVal, d := types.ListValue(runbookType, elements)
+	diags.Append(d...)
+	return listVal, diags
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func createFakeRunbooksTestServer() clientFunc {
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		// GET /api/runbooks endpoint
+		if req.Method == http.MethodGet && req.URL.Path == "/api/runbooks" {
+			return httpTestOk(http.StatusOK, hoop.RunbookList{
+				Repositories: []hoop.RunbookRepositoryList{
+					{
+						Repository: "github.com/hoophq/runbooks",
+						Commit:     "9a4c1f2b7e",
+						Items: []hoop.Runbook{
+							{Name: "postgres-demo/update-customer-email.runbook.sql", Metadata: map[string]hoop.RunbookParameter{
+								"customer_id": {Type: "number", Description: "the id of the customer", Required: true},
+								"email":       {Type: "email", Description: "the new email of the customer", Required: true},
+							}},
+							{Name: "postgres-demo/fetch-customer.runbook.sql", Metadata: map[string]hoop.RunbookParameter{
+								"status": {Type: "select", Default: "active", Options: []string{"active", "inactive"}},
+							}},
+							{Name: "ops/restart-service.runbook.sh"},
+						},
+					},
+					{
+						Repository: "github.com/your-org/your-repo",
+						Items:      []hoop.Runbook{{Name: "hello.runbook.sh"}},
+					},
+				},
+			}), nil
+		}
+		return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+	})
+}
+
+func TestRunbooksDataSource(t *testing.T) {
+	runbooksServer := createFakeRunbooksTestServer()
+	rulesServer := createFakeRunbookRulesTestServer()
+	httpClient := clientFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasPrefix(req.URL.Path, "/api/runbooks/rules") {
+			return rulesServer.Do(req)
+		}
+		return runbooksServer.Do(req)
+	})
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", httpClient)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_runbooks" "all" {
+  repository = "github.com/hoophq/runbooks"
+}
+
+data "hoop_runbooks" "postgres" {
+  repository = "github.com/hoophq/runbooks"
+  glob       = "postgres-demo/*.runbook.sql"
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hoop_runbooks.all", "id", "github.com/hoophq/runbooks"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.all", "commit", "9a4c1f2b7e"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.all", "runbooks.#", "3"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.all", "runbooks.0.name", "ops/restart-service.runbook.sh"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.all", "runbooks.0.parameters.%", "0"),
+
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.#", "2"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.0.name", "postgres-demo/fetch-customer.runbook.sql"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.0.repository", "github.com/hoophq/runbooks"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.0.parameters.status.type", "select"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.0.parameters.status.default", "active"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.0.parameters.status.options.#", "2"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.1.name", "postgres-demo/update-customer-email.runbook.sql"),
+					resource.TestCheckResourceAttr("data.hoop_runbooks.postgres", "runbooks.1.parameters.email.required", "true"),
+				),
+			},
+			// The runbooks could be assigned to a runbook rule
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_runbooks" "postgres" {
+  repository = "github.com/hoophq/runbooks"
+  glob       = "postgres-demo/*.runbook.sql"
+}
+
+resource "hoop_runbook_rule" "postgres" {
+  name        = "postgres-demo"
+  description = "Runbooks of the postgres demo"
+  connections = ["pgdemo"]
+  user_groups = ["dba"]
+  runbooks    = data.hoop_runbooks.postgres.runbooks
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_runbook_rule.postgres", "runbooks.#", "2"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.postgres", "runbooks.0.repository", "github.com/hoophq/runbooks"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.postgres", "runbooks.0.name", "postgres-demo/fetch-customer.runbook.sql"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.postgres", "runbooks.0.match", "exact"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.postgres", "runbooks.1.name", "postgres-demo/update-customer-email.runbook.sql"),
+				),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_runbooks" "unknown" {
+  repository = "github.com/hoophq/unknown"
+}`,
+				ExpectError: regexp.MustCompile(`repository "github.com/hoophq/unknown" not found`),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+data "hoop_runbooks" "invalid" {
+  repository = "github.com/hoophq/runbooks"
+  glob       = "postgres-demo/[.sql"
+}`,
+				ExpectError: regexp.MustCompile(`Invalid Glob Pattern`),
+			},
+		},
+	})
+}
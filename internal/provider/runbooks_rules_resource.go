@@ -4,16 +4,21 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
 )
 
@@ -21,6 +26,7 @@ import (
 var (
 	_ resource.Resource                = &runbookRulesResource{}
 	_ resource.ResourceWithImportState = &runbookRulesResource{}
+	_ resource.ResourceWithModifyPlan  = &runbookRulesResource{}
 )
 
 // NewRunbookRulesResource is a helper function to simplify the provider implementation.
@@ -33,11 +39,24 @@ type runbooksRulesResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
-	Connections types.List   `tfsdk:"connections"`
-	UserGroups  types.List   `tfsdk:"user_groups"`
+	Connections types.Set    `tfsdk:"connections"`
+	UserGroups  types.Set    `tfsdk:"user_groups"`
 	Runbooks    types.List   `tfsdk:"runbooks"`
 }
 
+// runbookRuleItemModel maps the items of the runbooks attribute.
+type runbookRuleItemModel struct {
+	Name       types.String `tfsdk:"name"`
+	Repository types.String `tfsdk:"repository"`
+	Match      types.String `tfsdk:"match"`
+}
+
+var runbookRuleItemAttrTypes = map[string]attr.Type{
+	"name":       types.StringType,
+	"repository": types.StringType,
+	"match":      types.StringType,
+}
+
 // runbookRulesResource is the data source implementation.
 type runbookRulesResource struct {
 	client *hoop.Client
@@ -52,6 +71,7 @@ func (r *runbookRulesResource) Metadata(_ context.Context, req resource.Metadata
 func (r *runbookRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages Runbook Rules resources. It allows defining which connections and groups could interact with runbooks. Make sure to work with this resource only with the gateway version 1.47.0 and onwards",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the connection resource.",
@@ -68,14 +88,14 @@ func (r *runbookRulesResource) Schema(_ context.Context, _ resource.SchemaReques
 				Description: "The description of the rule.",
 				Required:    true,
 			},
-			"connections": schema.ListAttribute{
+			"connections": schema.SetAttribute{
 				ElementType: types.StringType,
-				Description: "List of connection names which this rule applies to.",
+				Description: "Set of connection names which this rule applies to.",
 				Required:    true,
 			},
-			"user_groups": schema.ListAttribute{
+			"user_groups": schema.SetAttribute{
 				ElementType: types.StringType,
-				Description: "List of user groups names which this rule applies to.",
+				Description: "Set of user groups names which this rule applies to.",
 				Required:    true,
 			},
 			"runbooks": schema.ListNestedAttribute{
@@ -84,13 +104,26 @@ func (r *runbookRulesResource) Schema(_ context.Context, _ resource.SchemaReques
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"name": schema.StringAttribute{
-							Required:    true,
-							Description: "The relative git path of the runbook file.",
+							Required: true,
+							Description: "The relative git path of the runbook file. " +
+								"It's a glob pattern or a path prefix depending on the `match` attribute.",
 						},
 						"repository": schema.StringAttribute{
 							Required:    true,
 							Description: "The normalized name of the repository. E.g.: 'github.com/hoophq/runbooks'",
 						},
+						"match": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Description: "How the name matches the runbooks of the repository. " +
+								"`exact` matches a single runbook file, `glob` matches a glob pattern, e.g.: `postgres-demo/*.runbook.sql`, " +
+								"where the `*` wildcard doesn't match the path separator `/`, " +
+								"and `prefix` matches every runbook starting with the name, e.g.: `postgres-demo/`. Defaults to `exact`.",
+							Default: stringdefault.StaticString(hoop.RunbookMatchExact),
+							Validators: []validator.String{
+								stringvalidator.OneOf(hoop.RunbookMatchExact, hoop.RunbookMatchGlob, hoop.RunbookMatchPrefix),
+							},
+						},
 					},
 				},
 			},
@@ -108,6 +141,33 @@ func (r *runbookRulesResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	// a rule moved from a hoop_plugin_connection resource is resolved on the first read
+	if currentState.ID.IsNull() {
+		movedConnectionID, diags := req.Private.GetKey(ctx, movedConnectionIDPrivateKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var connectionID string
+		if err := json.Unmarshal(movedConnectionID, &connectionID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Runbook Rule",
+				fmt.Sprintf("Failed decoding the connection id of the moved plugin connection: %v", err),
+			)
+			return
+		}
+		migratedRule, err := findMigratedRunbookRule(r.client, connectionID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Runbook Rule",
+				fmt.Sprintf("Failed resolving runbook rule moved from the plugin connection: %v", err),
+			)
+			return
+		}
+		currentState.ID = types.StringValue(migratedRule.ID)
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, movedConnectionIDPrivateKey, nil)...)
+	}
+
 	rule, err := r.client.GetRunbookRuleByID(currentState.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -120,7 +180,7 @@ func (r *runbookRulesResource) Read(ctx context.Context, req resource.ReadReques
 	currentState.ID = types.StringValue(rule.ID)
 	currentState.Name = types.StringValue(rule.Name)
 	currentState.Description = types.StringValue(rule.Description)
-	currentState.Connections, diags = types.ListValueFrom(ctx, types.StringType, rule.Connections)
+	currentState.Connections, diags = types.SetValueFrom(ctx, types.StringType, rule.Connections)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection Names",
@@ -128,7 +188,7 @@ func (r *runbookRulesResource) Read(ctx context.Context, req resource.ReadReques
 		)
 		return
 	}
-	currentState.UserGroups, diags = types.ListValueFrom(ctx, types.StringType, rule.UserGroups)
+	currentState.UserGroups, diags = types.SetValueFrom(ctx, types.StringType, rule.UserGroups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting User Group Names",
@@ -175,7 +235,7 @@ func (r *runbookRulesResource) Create(ctx context.Context, req resource.CreateRe
 		}
 	}
 
-	connectionNames, diags := convertListToStringSlice(ctx, plan.Connections)
+	connectionNames, diags := convertSetToStringSlice(ctx, plan.Connections)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection Names",
@@ -184,7 +244,7 @@ func (r *runbookRulesResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	userGroups, diags := convertListToStringSlice(ctx, plan.UserGroups)
+	userGroups, diags := convertSetToStringSlice(ctx, plan.UserGroups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting User Group Names",
@@ -240,7 +300,7 @@ func (r *runbookRulesResource) Update(ctx context.Context, req resource.UpdateRe
 		}
 	}
 
-	connectionNames, diags := convertListToStringSlice(ctx, plan.Connections)
+	connectionNames, diags := convertSetToStringSlice(ctx, plan.Connections)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Connection Names",
@@ -249,7 +309,7 @@ func (r *runbookRulesResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	userGroups, diags := convertListToStringSlice(ctx, plan.UserGroups)
+	userGroups, diags := convertSetToStringSlice(ctx, plan.UserGroups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting User Group Names",
@@ -300,8 +360,143 @@ func (r *runbookRulesResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
+// ModifyPlan validates the references of the rule when their values are known:
+// the connections and the repositories should exist, and the glob and prefix patterns must
+// match at least one runbook of the repository. The validations are skipped when the
+// gateway could not list the referenced resources or when the rule is unchanged.
+func (r *runbookRulesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the resource is being destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	// the rule is unchanged, the references were validated when it was applied
+	if req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var plan runbooksRulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.validateConnections(ctx, plan.Connections, resp)
+	r.validateRunbooks(ctx, plan.Runbooks, resp)
+}
+
+// validateConnections warns about connections that don't exist. It's not an error because
+// connection names are known when planning, even when the connection is created in the same apply.
+func (r *runbookRulesResource) validateConnections(ctx context.Context, connections types.Set, resp *resource.ModifyPlanResponse) {
+	if connections.IsNull() || connections.IsUnknown() {
+		return
+	}
+	for _, elem := range connections.Elements() {
+		connectionName, ok := elem.(types.String)
+		if !ok || connectionName.IsUnknown() || connectionName.IsNull() {
+			continue
+		}
+		_, err := r.client.GetConnection(connectionName.ValueString())
+		switch {
+		case hoop.IsNotFound(err):
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("connections").AtSetValue(connectionName),
+				"Connection Not Found",
+				fmt.Sprintf("The connection %q does not exist, the rule grants nothing on it "+
+					"unless the connection is created before applying.", connectionName.ValueString()),
+			)
+		case err != nil:
+			tflog.Debug(ctx, "skipping the validation of the connection, unable to read it",
+				map[string]any{"connection": connectionName.ValueString(), "error": err.Error()})
+		}
+	}
+}
+
+// validateRunbooks warns about repositories without a runbook configuration, they could be
+// configured in the same apply, and rejects glob or prefix patterns matching no runbook of the repository.
+func (r *runbookRulesResource) validateRunbooks(ctx context.Context, runbooks types.List, resp *resource.ModifyPlanResponse) {
+	if runbooks.IsNull() || runbooks.IsUnknown() {
+		return
+	}
+	var items []runbookRuleItemModel
+	resp.Diagnostics.Append(runbooks.ElementsAs(ctx, &items, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configuredRepositories map[string]struct{}
+	repos, err := r.client.ListRunbookRepos()
+	if err != nil {
+		tflog.Debug(ctx, "skipping the validation of the runbook repositories, unable to list runbook configurations",
+			map[string]any{"error": err.Error()})
+	} else {
+		configuredRepositories = map[string]struct{}{}
+		for _, repo := range repos {
+			configuredRepositories[repo.Repository] = struct{}{}
+		}
+	}
+
+	repositories := map[string]*hoop.RunbookRepositoryList{}
+	for i, item := range items {
+		if item.Repository.IsUnknown() {
+			continue
+		}
+		repository := item.Repository.ValueString()
+		if _, ok := configuredRepositories[repository]; configuredRepositories != nil && !ok {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("runbooks").AtListIndex(i).AtName("repository"),
+				"Runbook Repository Not Found",
+				fmt.Sprintf("The repository %q has no runbook configuration, the rule grants nothing on it "+
+					"unless the repository is configured before applying. "+
+					"Use the repository attribute of a hoop_runbook_configuration resource.", repository),
+			)
+			continue
+		}
+		if item.Name.IsUnknown() || item.Match.IsUnknown() || item.Match.ValueString() == hoop.RunbookMatchExact {
+			continue
+		}
+
+		repo, ok := repositories[repository]
+		if !ok {
+			repo, err = r.client.ListRunbooks(repository)
+			if err != nil {
+				tflog.Debug(ctx, "skipping the validation of runbook patterns, unable to list runbooks",
+					map[string]any{"repository": repository, "error": err.Error()})
+			}
+			repositories[repository] = repo
+		}
+		if repo == nil {
+			continue
+		}
+
+		ruleItem := hoop.RunbookRuleItem{Name: item.Name.ValueString(), Repository: repository, Match: item.Match.ValueString()}
+		matched, err := runbookRuleItemMatchesAny(ruleItem, repo.Items)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("runbooks").AtListIndex(i).AtName("name"),
+				"Invalid Runbook Pattern",
+				fmt.Sprintf("The %s pattern %q is invalid: %v", ruleItem.Match, ruleItem.Name, err),
+			)
+			continue
+		}
+		if !matched {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("runbooks").AtListIndex(i).AtName("name"),
+				"Runbook Pattern Matches No Runbooks",
+				fmt.Sprintf("The %s pattern %q matches no runbook of the repository %q. "+
+					"Use the hoop_runbooks data source to list the runbooks of the repository.", ruleItem.Match, ruleItem.Name, repository),
+			)
+		}
+	}
+}
+
+// ImportState imports a runbook rule by its id or by its name with the name: prefix.
 func (r *runbookRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByIDOrName(ctx, req, resp, "Runbook Rule", func(name string) (string, error) {
+		rule, err := r.client.GetRunbookRuleByName(name)
+		if err != nil {
+			return "", err
+		}
+		return rule.ID, nil
+	})
 }
 
 // Configure adds the provider configured client to the data source.
@@ -312,16 +507,16 @@ func (r *runbookRulesResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
+	r.client = data.client
 }
 
 func toApiRunbookRulesItemList(items types.List) ([]hoop.RunbookRuleItem, error) {
@@ -350,10 +545,16 @@ func toApiRunbookRulesItemList(items types.List) ([]hoop.RunbookRuleItem, error)
 		if !ok {
 			return nil, fmt.Errorf("failed to convert 'repository' attribute to string, found=%T", repositoryVal)
 		}
+		// prior schema versions don't have the match attribute
+		var match string
+		if matchVal, ok := attrs["match"].(types.String); ok {
+			match = matchVal.ValueString()
+		}
 
 		runbookRuleItems = append(runbookRuleItems, hoop.RunbookRuleItem{
 			Name:       name.ValueString(),
 			Repository: repository.ValueString(),
+			Match:      match,
 		})
 	}
 
@@ -362,12 +563,7 @@ func toApiRunbookRulesItemList(items types.List) ([]hoop.RunbookRuleItem, error)
 
 func fromApiRunbookRulesItemList(runbookRuleItems []hoop.RunbookRuleItem) (types.List, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	objectType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"name":       types.StringType,
-			"repository": types.StringType,
-		},
-	}
+	objectType := types.ObjectType{AttrTypes: runbookRuleItemAttrTypes}
 
 	// If the slice is empty, return an empty list
 	if len(runbookRuleItems) == 0 {
@@ -379,15 +575,17 @@ func fromApiRunbookRulesItemList(runbookRuleItems []hoop.RunbookRuleItem) (types
 	// Convert each API entry to a Terraform object
 	var objectValues []attr.Value
 	for _, entry := range runbookRuleItems {
-		// Create the object with name and entity_types attributes
+		// gateways without support for patterns don't return the match attribute
+		match := entry.Match
+		if match == "" {
+			match = hoop.RunbookMatchExact
+		}
 		objectValue, d := types.ObjectValue(
-			map[string]attr.Type{
-				"name":       types.StringType,
-				"repository": types.StringType,
-			},
+			runbookRuleItemAttrTypes,
 			map[string]attr.Value{
 				"name":       types.StringValue(entry.Name),
 				"repository": types.StringValue(entry.Repository),
+				"match":      types.StringValue(match),
 			},
 		)
 		if d.HasError() {
@@ -402,3 +600,16 @@ func fromApiRunbookRulesItemList(runbookRuleItems []hoop.RunbookRuleItem) (types
 	diags.Append(d...)
 	return resultList, diags
 }
+
+// runbookRuleItemMatchesAny reports whether the item matches any of the runbooks.
+func runbookRuleItemMatchesAny(item hoop.RunbookRuleItem, runbooks []hoop.Runbook) (bool, error) {
+	for _, runbook := range runbooks {
+		matched, err := item.Matches(runbook.Name)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	// validate the pattern when there are no runbooks to match
+	_, err := item.Matches("")
+	return false, err
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+var _ resource.ResourceWithMoveState = &runbookRulesResource{}
+
+// movedConnectionIDPrivateKey is the private state key that holds the connection id
+// of a moved hoop_plugin_connection until the runbook rule is resolved by Read.
+const movedConnectionIDPrivateKey = "moved_plugin_connection_id"
+
+// pluginConnectionSourceSchema is the schema of the hoop_plugin_connection resource
+// used to decode the source state when moving it to a runbook rule.
+func pluginConnectionSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"plugin_name":   schema.StringAttribute{Required: true},
+			"connection_id": schema.StringAttribute{Required: true},
+			"config":        schema.ListAttribute{Required: true, ElementType: types.StringType},
+		},
+	}
+}
+
+// MoveState allows moving a hoop_plugin_connection of the runbooks plugin to this resource.
+func (r *runbookRulesResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			SourceSchema: pluginConnectionSourceSchema(),
+			StateMover:   moveRunbookRuleStateFromPluginConnection,
+		},
+	}
+}
+
+// moveRunbookRuleStateFromPluginConnection translates the list of runbook paths of a legacy plugin connection
+// into the runbooks of the rule. The provider is not configured when moving states, the rule that the gateway
+// created when migrating the runbooks plugin is resolved by Read using the connection id kept in the private state.
+func moveRunbookRuleStateFromPluginConnection(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "hoop_plugin_connection" {
+		return
+	}
+
+	var source pluginConnectionResourceModel
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if source.PluginName.ValueString() != "runbooks" {
+		resp.Diagnostics.AddError(
+			"Unsupported Move State",
+			fmt.Sprintf("Only plugin connections of the runbooks plugin can be moved to hoop_runbook_rule, got plugin %q",
+				source.PluginName.ValueString()),
+		)
+		return
+	}
+
+	runbookPaths, diags := convertListToStringSlice(ctx, source.Config)
+	if diags.HasError() {
+		resp.Diagnostics.AddError(
+			"Error Converting Config List",
+			fmt.Sprintf("Failed to convert config list: %v", diags),
+		)
+		return
+	}
+
+	// the repository is refreshed from the migrated rule
+	var runbookItems []hoop.RunbookRuleItem
+	for _, runbookPath := range runbookPaths {
+		runbookItems = append(runbookItems, hoop.RunbookRuleItem{Name: runbookPath})
+	}
+
+	state := runbooksRulesResourceModel{
+		ID:          types.StringNull(),
+		Name:        types.StringNull(),
+		Description: types.StringNull(),
+		Connections: types.SetNull(types.StringType),
+		UserGroups:  types.SetNull(types.StringType),
+	}
+	state.Runbooks, diags = fromApiRunbookRulesItemList(runbookItems)
+	if diags.HasError() {
+		resp.Diagnostics.AddError(
+			"Error Converting Runbook Rule Items",
+			fmt.Sprintf("Failed to convert Runbook Rule Items: %v", diags),
+		)
+		return
+	}
+
+	connectionID, err := json.Marshal(source.ConnectionID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Moving Plugin Connection",
+			fmt.Sprintf("Failed encoding connection id: %v", err),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.TargetPrivate.SetKey(ctx, movedConnectionIDPrivateKey, connectionID)...)
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, state)...)
+}
+
+// findMigratedRunbookRule returns the runbook rule that the gateway created
+// when migrating the runbooks plugin of the connection.
+func findMigratedRunbookRule(client *hoop.Client, connectionID string) (*hoop.RunbookRule, error) {
+	conn, err := client.GetConnection(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading connection %q: %w", connectionID, err)
+	}
+	rules, err := client.ListRunbookRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed listing runbook rules: %w", err)
+	}
+	var matchedRules []hoop.RunbookRule
+	for _, rule := range rules {
+		if slices.Contains(rule.Connections, conn.Name) {
+			matchedRules = append(matchedRules, rule)
+		}
+	}
+	if len(matchedRules) != 1 {
+		return nil, fmt.Errorf("expected a single runbook rule for the connection %q, found %v, "+
+			"remove the moved block and import the hoop_runbook_rule resource instead", conn.Name, len(matchedRules))
+	}
+	return &matchedRules[0], nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func createFakeRunbookRulesMoveTestServer() clientFunc {
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet {
+			return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+		}
+		switch req.URL.Path {
+		// GET /api/connections/{id} endpoint
+		case "/api/connections/6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1":
+			return httpTestOk(http.StatusOK, hoop.Connection{ID: "6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1", Name: "pgdemo"}), nil
+		case "/api/connections/0c8a8b1e-2f4b-4a8e-8a55-7b1e1c5b9f77":
+			return httpTestOk(http.StatusOK, hoop.Connection{ID: "0c8a8b1e-2f4b-4a8e-8a55-7b1e1c5b9f77", Name: "pgstaging"}), nil
+		// GET /api/runbooks/rules endpoint
+		case "/api/runbooks/rules":
+			return httpTestOk(http.StatusOK, []hoop.RunbookRule{
+				{ID: "f0c1a9c6-2d6f-4e0e-9d43-0b9f8e6f7a10", Name: "pgprod", Connections: []string{"pgprod"}},
+				{
+					ID:          runbookRuleResourceFakeID,
+					Name:        "pgdemo",
+					Description: "Auto-migrated from Runbooks",
+					Connections: []string{"pgdemo"},
+				},
+			}), nil
+		}
+		return httpTestErr(http.StatusNotFound, `resource not found`), nil
+	})
+}
+
+func TestRunbookRulesResourceMoveStateFromPluginConnection(t *testing.T) {
+	var state runbooksRulesResourceModel
+	resp := moveTestState(t, "hoop_plugin_connection", NewRunbookRulesResource, `{
+  "plugin_name": "runbooks",
+  "connection_id": "6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1",
+  "config": ["pgdemo/fetch-customer-by-id.runbook.sql", "pgdemo/update-customer-email.runbook.sql"]
+}`, &state)
+
+	if !state.ID.IsNull() {
+		t.Errorf("expected id to be resolved by read, got %v", state.ID)
+	}
+	var private map[string][]byte
+	if err := json.Unmarshal(resp.TargetPrivate, &private); err != nil {
+		t.Fatalf("failed decoding private state: %v", err)
+	}
+	if got := string(private[movedConnectionIDPrivateKey]); got != `"6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1"` {
+		t.Errorf("expected the connection id to be kept in the private state, got %v", got)
+	}
+
+	runbookItems, err := toApiRunbookRulesItemList(state.Runbooks)
+	if err != nil {
+		t.Fatalf("failed converting runbooks: %v", err)
+	}
+	if len(runbookItems) != 2 ||
+		runbookItems[0].Name != "pgdemo/fetch-customer-by-id.runbook.sql" ||
+		runbookItems[1].Name != "pgdemo/update-customer-email.runbook.sql" {
+		t.Errorf("expected runbooks to be translated from the plugin config, got %v", runbookItems)
+	}
+}
+
+func TestFindMigratedRunbookRule(t *testing.T) {
+	client := hoop.NewClient("http://localhost:8009/api", "xapi-hash", createFakeRunbookRulesMoveTestServer())
+
+	rule, err := findMigratedRunbookRule(client, "6d2cdb3c-b4e1-4c0d-9a1a-1bbf6ac8e1a1")
+	if err != nil {
+		t.Fatalf("failed finding migrated rule: %v", err)
+	}
+	if rule.ID != runbookRuleResourceFakeID {
+		t.Errorf("expected rule id to be %v, got %v", runbookRuleResourceFakeID, rule.ID)
+	}
+
+	if _, err := findMigratedRunbookRule(client, "0c8a8b1e-2f4b-4a8e-8a55-7b1e1c5b9f77"); err == nil {
+		t.Errorf("expected error for a connection without a migrated rule")
+	}
+}
@@ -2,7 +2,9 @@ package provider
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -17,6 +19,14 @@ var runbookRuleResourceFakeID = "3f3cb338-b812-4e31-b6c2-c79a08cc3221"
 func createFakeRunbookRulesTestServer() clientFunc {
 	store := map[string]*hoop.RunbookRule{}
 	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		// GET /api/runbooks/rules endpoint
+		if req.Method == http.MethodGet && req.URL.Path == "/api/runbooks/rules" {
+			rules := []*hoop.RunbookRule{}
+			for _, rule := range store {
+				rules = append(rules, rule)
+			}
+			return httpTestOk(http.StatusOK, rules), nil
+		}
 		switch req.Method {
 		// POST /api/runbooks/rules endpoint
 		case http.MethodPost:
@@ -97,12 +107,13 @@ resource "hoop_runbook_rule" "myrule" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "name", "My Rule"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "description", "My Rule Description"),
-					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "connections.0", "pgdemo"),
-					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "user_groups.0", "developers"),
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "connections.*", "pgdemo"),
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "user_groups.*", "developers"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.repository", "normalized-git-url-repo"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.name", "postgres-demo/update-customer-email.runbook.sql"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.1.repository", "normalized-git-url-repo"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.1.name", "postgres-demo/delete-customer-by-id.runbook.sql"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.1.match", "exact"),
 
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hoop_runbook_rule.myrule", "id"),
@@ -139,10 +150,10 @@ resource "hoop_runbook_rule" "myrule" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "name", "My Rule"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "description", "My Rule Description Updated"),
-					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "connections.0", "pgdemo"),
-					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "connections.1", "pgprod"),
-					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "user_groups.0", "developers"),
-					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "user_groups.1", "dba"),
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "connections.*", "pgdemo"),
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "connections.*", "pgprod"),
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "user_groups.*", "developers"),
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "user_groups.*", "dba"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.repository", "normalized-git-url-repo"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.name", "postgres-demo/run-migration.sql"),
 					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.1.repository", "normalized-git-url-repo"),
@@ -162,6 +173,162 @@ resource "hoop_runbook_rule" "myrule" {
 				ImportStateVerifyIdentifierAttribute: "id",
 				ImportStateId:                        runbookRuleResourceFakeID,
 			},
+			// ImportState testing by name
+			{
+				ResourceName:                         "hoop_runbook_rule.myrule",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "id",
+				ImportStateId:                        "name:My Rule",
+			},
+		},
+	})
+}
+
+func TestRunbooksRulesResourcePatterns(t *testing.T) {
+	rulesServer := createFakeRunbookRulesTestServer()
+	runbooksServer := createFakeRunbooksTestServer()
+	runbooksRemoved := false
+	httpClient := clientFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/api/runbooks" && runbooksRemoved {
+			return httpTestOk(http.StatusOK, hoop.RunbookList{
+				Repositories: []hoop.RunbookRepositoryList{{Repository: "github.com/hoophq/runbooks"}},
+			}), nil
+		}
+		if req.URL.Path == "/api/runbooks" {
+			return runbooksServer.Do(req)
+		}
+		return rulesServer.Do(req)
+	})
+	ruleConfig := `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_rule" "myrule" {
+  name        = "My Rule"
+  description = "My Rule Description"
+  connections = ["pgdemo"]
+  user_groups = ["developers"]
+  runbooks = [
+    {
+      repository = "github.com/hoophq/runbooks"
+      name       = "postgres-demo/*.runbook.sql"
+      match      = "glob"
+    },
+    {
+      repository = "github.com/hoophq/runbooks"
+      name       = "ops/"
+      match      = "prefix"
+    }
+  ]
+}`
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", httpClient)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: ruleConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.name", "postgres-demo/*.runbook.sql"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.match", "glob"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.1.name", "ops/"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.1.match", "prefix"),
+				),
+			},
+			// the patterns of an unchanged rule are not validated again
+			{
+				PreConfig: func() { runbooksRemoved = true },
+				Config:    ruleConfig,
+				PlanOnly:  true,
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_rule" "myrule" {
+  name        = "My Rule"
+  description = "My Rule Description"
+  connections = ["pgdemo"]
+  user_groups = ["developers"]
+  runbooks = [
+    {
+      repository = "github.com/hoophq/runbooks"
+      name       = "mysql-demo/*.runbook.sql"
+      match      = "glob"
+    }
+  ]
+}`,
+				ExpectError: regexp.MustCompile(`Runbook Pattern Matches No Runbooks`),
+			},
+		},
+	})
+}
+
+func TestRunbooksRulesResourceReferences(t *testing.T) {
+	rulesServer := createFakeRunbookRulesTestServer()
+	httpClient := clientFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		// GET /api/connections/{name} endpoint
+		case req.Method == http.MethodGet && strings.HasPrefix(req.URL.Path, "/api/connections/"):
+			name := strings.TrimPrefix(req.URL.Path, "/api/connections/")
+			if name != "pgdemo" {
+				return httpTestErr(http.StatusNotFound, `connection %q not found`, name), nil
+			}
+			return httpTestOk(http.StatusOK, hoop.Connection{ID: "c8d2a3e4-0c5c-4d5e-9a3f-9b1d2e3f4a5b", Name: name}), nil
+		// GET /api/runbooks/configurations endpoint
+		case req.Method == http.MethodGet && req.URL.Path == "/api/runbooks/configurations":
+			return httpTestOk(http.StatusOK, hoop.RunbookConfig{
+				Repositories: []hoop.RunbookRepo{{Repository: "github.com/hoophq/runbooks", GitURL: "https://github.com/hoophq/runbooks.git"}},
+			}), nil
+		}
+		return rulesServer.Do(req)
+	})
+	resourceConfig := func(connection, repository string) string {
+		return fmt.Sprintf(`
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_runbook_rule" "myrule" {
+  name        = "My Rule"
+  description = "My Rule Description"
+  connections = [%q]
+  user_groups = ["developers"]
+  runbooks = [
+    {
+      repository = %q
+      name       = "postgres-demo/update-customer-email.runbook.sql"
+    }
+  ]
+}`, connection, repository)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", httpClient)()),
+		},
+		Steps: []resource.TestStep{
+			// a repository without a runbook configuration is only a warning
+			{
+				Config: resourceConfig("pgdemo", "github.com/hoophq/runbok"),
+				Check:  resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.repository", "github.com/hoophq/runbok"),
+			},
+			// a connection that doesn't exist is only a warning
+			{
+				Config: resourceConfig("pgdem", "github.com/hoophq/runbooks"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("hoop_runbook_rule.myrule", "connections.*", "pgdem"),
+					resource.TestCheckResourceAttr("hoop_runbook_rule.myrule", "runbooks.0.repository", "github.com/hoophq/runbooks"),
+				),
+			},
 		},
 	})
 }
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &runbookRulesResource{}
+
+// runbooksRulesResourceModelV0 maps the schema version 0 of the runbook rule resource,
+// where connections and user_groups were lists.
+type runbooksRulesResourceModelV0 struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Connections types.List   `tfsdk:"connections"`
+	UserGroups  types.List   `tfsdk:"user_groups"`
+	Runbooks    types.List   `tfsdk:"runbooks"`
+}
+
+// runbookRulesSchemaV0 is the schema version 0 of the runbook rule resource.
+// It must not be changed, it's only used to decode prior states.
+func runbookRulesSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.StringAttribute{Computed: true},
+			"name":        schema.StringAttribute{Required: true},
+			"description": schema.StringAttribute{Required: true},
+			"connections": schema.ListAttribute{Required: true, ElementType: types.StringType},
+			"user_groups": schema.ListAttribute{Required: true, ElementType: types.StringType},
+			"runbooks": schema.ListNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":       schema.StringAttribute{Required: true},
+						"repository": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState upgrades prior states of the runbook rule resource to the current schema version.
+func (r *runbookRulesResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: runbookRulesSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState runbooksRulesResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState, diags := upgradeRunbookRulesStateV0(ctx, priorState)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+	}
+}
+
+// upgradeRunbookRulesStateV0 converts the list attributes of the schema version 0 to sets.
+// The runbooks of the schema version 0 match the exact relative git path.
+func upgradeRunbookRulesStateV0(ctx context.Context, prior runbooksRulesResourceModelV0) (state runbooksRulesResourceModel, diags diag.Diagnostics) {
+	state = runbooksRulesResourceModel{
+		ID:          prior.ID,
+		Name:        prior.Name,
+		Description: prior.Description,
+	}
+	runbookItems, err := toApiRunbookRulesItemList(prior.Runbooks)
+	if err != nil {
+		diags.AddError("Error Converting Runbook Rule Items", fmt.Sprintf("Failed to convert runbook rule items: %v", err))
+		return
+	}
+	state.Runbooks, diags = fromApiRunbookRulesItemList(runbookItems)
+	if diags.HasError() {
+		return
+	}
+	state.Connections, diags = convertListToSet(ctx, prior.Connections)
+	if diags.HasError() {
+		return
+	}
+	state.UserGroups, diags = convertListToSet(ctx, prior.UserGroups)
+	return
+}
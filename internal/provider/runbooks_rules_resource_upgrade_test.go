@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+func TestRunbookRulesResourceUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	var state runbooksRulesResourceModel
+	upgradeTestState(t, NewRunbookRulesResource, 0, `{
+  "id": "b0a3a8a0-3b40-4d47-9a3e-8a1c1b6c1e11",
+  "name": "default",
+  "description": "default rule",
+  "connections": ["pgprod", "pgdemo"],
+  "user_groups": ["developers"],
+  "runbooks": [{"name": "ops/update-user.runbook.sh", "repository": "github.com/hoophq/runbooks"}]
+}`, &state)
+
+	if state.Name.ValueString() != "default" || len(state.Runbooks.Elements()) != 1 {
+		t.Errorf("expected attributes to be preserved, got name=%v, runbooks=%v", state.Name, state.Runbooks)
+	}
+
+	var runbooks []runbookRuleItemModel
+	if diags := state.Runbooks.ElementsAs(ctx, &runbooks, false); diags.HasError() {
+		t.Fatalf("failed converting runbooks: %v", diags)
+	}
+	if runbooks[0].Match.ValueString() != "exact" {
+		t.Errorf("expected runbooks to match the exact path, got %v", runbooks[0].Match)
+	}
+
+	connections, diags := convertSetToStringSlice(ctx, state.Connections)
+	if diags.HasError() {
+		t.Fatalf("failed converting connections: %v", diags)
+	}
+	sort.Strings(connections)
+	if len(connections) != 2 || connections[0] != "pgdemo" || connections[1] != "pgprod" {
+		t.Errorf("expected connections to be a set of [pgdemo pgprod], got %v", connections)
+	}
+
+	userGroups, diags := convertSetToStringSlice(ctx, state.UserGroups)
+	if diags.HasError() {
+		t.Fatalf("failed converting user groups: %v", diags)
+	}
+	if len(userGroups) != 1 || userGroups[0] != "developers" {
+		t.Errorf("expected user groups to be a set of [developers], got %v", userGroups)
+	}
+}
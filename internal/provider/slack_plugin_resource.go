@@ -0,0 +1,450 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &slackPluginResource{}
+	_ resource.ResourceWithConfigure      = &slackPluginResource{}
+	_ resource.ResourceWithImportState    = &slackPluginResource{}
+	_ resource.ResourceWithUpgradeState   = &slackPluginResource{}
+	_ resource.ResourceWithValidateConfig = &slackPluginResource{}
+)
+
+// NewSlackPluginResource is a helper function to simplify the provider implementation.
+func NewSlackPluginResource() resource.Resource {
+	return &slackPluginResource{}
+}
+
+// slackPluginResourceModel maps the resource schema data.
+type slackPluginResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	BotToken        types.String `tfsdk:"bot_token"`
+	AppToken        types.String `tfsdk:"app_token"`
+	DefaultChannels types.List   `tfsdk:"default_channels"`
+	Connections     types.Set    `tfsdk:"connections"`
+}
+
+// slackPluginConnectionModel maps the channels a connection sends its reviews to.
+type slackPluginConnectionModel struct {
+	ConnectionID types.String `tfsdk:"connection_id"`
+	Channels     types.List   `tfsdk:"channels"`
+}
+
+var slackPluginConnectionAttrTypes = map[string]attr.Type{
+	"connection_id": types.StringType,
+	"channels":      types.ListType{ElemType: types.StringType},
+}
+
+// slackPluginResource is the resource implementation.
+type slackPluginResource struct {
+	client *hoop.Client
+}
+
+// Metadata returns the resource type name.
+func (r *slackPluginResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_slack_plugin"
+}
+
+// Schema defines the schema for the resource.
+func (r *slackPluginResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the Slack plugin, which sends the reviews of the sessions to Slack channels. " +
+			"It replaces the `hoop_plugin_config` and `hoop_plugin_connection` resources of the `slack` plugin, don't use them together.",
+		Version: 0,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the plugin configuration.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bot_token": schema.StringAttribute{
+				Description: "The bot user OAuth token of the Slack app, starting with `xoxb-`.",
+				Required:    true,
+				Sensitive:   true,
+				Validators:  SlackBotTokenValidator,
+			},
+			"app_token": schema.StringAttribute{
+				Description: "The app-level token of the Slack app with the `connections:write` scope, starting with `xapp-`.",
+				Required:    true,
+				Sensitive:   true,
+				Validators:  SlackAppTokenValidator,
+			},
+			"default_channels": schema.ListAttribute{
+				Description: "The ids of the Slack channels the reviews are sent to when a connection of `connections` doesn't set its own channels. " +
+					"It requires `connections`, the connections of the plugin not managed by this resource keep their channels.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  SlackChannelsValidator,
+			},
+			"connections": schema.SetNestedAttribute{
+				Description: "The connections sending their reviews to Slack. " +
+					"When omitted the connections of the plugin are not managed by this resource.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"connection_id": schema.StringAttribute{
+							Description: "The unique identifier of the connection.",
+							Required:    true,
+							Validators:  NonEmptyStringValidator,
+						},
+						"channels": schema.ListAttribute{
+							Description: "The ids of the Slack channels the reviews of the connection are sent to. Defaults to `default_channels`.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators:  SlackChannelsValidator,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig requires the connections with the default channels, the connections
+// to be set once and the default channels when a connection doesn't set its own channels.
+func (r *slackPluginResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config slackPluginResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// the default channels are only sent with the connections of this resource
+	if !config.DefaultChannels.IsNull() && config.Connections.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_channels"),
+			"Missing Slack Connections",
+			"The default channels only apply to the connections of this resource, set connections or remove default_channels.",
+		)
+		return
+	}
+	if config.Connections.IsNull() || config.Connections.IsUnknown() {
+		return
+	}
+	connectionIDs := map[string]struct{}{}
+	for _, elem := range config.Connections.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		connectionID, ok := obj.Attributes()["connection_id"].(types.String)
+		if ok && !connectionID.IsNull() && !connectionID.IsUnknown() {
+			if _, found := connectionIDs[connectionID.ValueString()]; found {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("connections").AtSetValue(obj).AtName("connection_id"),
+					"Duplicate Slack Connection",
+					fmt.Sprintf("The connection %q is set more than once, set all of its channels in a single element.", connectionID.ValueString()),
+				)
+			}
+			connectionIDs[connectionID.ValueString()] = struct{}{}
+		}
+
+		channels, ok := obj.Attributes()["channels"].(types.List)
+		if !ok || !channels.IsNull() || !config.DefaultChannels.IsNull() {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("connections").AtSetValue(obj).AtName("channels"),
+			"Missing Slack Channels",
+			"The channels of the connection must be set when default_channels is not set.",
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *slackPluginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state slackPluginResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slackConfig, err := r.client.GetSlackPluginConfig()
+	if hoop.IsNotFound(err) {
+		tflog.Info(ctx, "slack plugin not found, removing it from the state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Slack Plugin",
+			fmt.Sprintf("Failed reading plugin %q: %v", hoop.SlackPluginName, err),
+		)
+		return
+	}
+	if slackConfig == nil {
+		tflog.Info(ctx, "slack plugin is not configured, removing it from the state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.ID = types.StringValue(slackConfig.ID)
+	state.BotToken = stringValueOrNull(slackConfig.BotToken)
+	state.AppToken = stringValueOrNull(slackConfig.AppToken)
+
+	// the connections are not managed by this resource
+	if state.Connections.IsNull() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	defaultChannels, diags := convertListToStringSlice(ctx, state.DefaultChannels)
+	resp.Diagnostics.Append(diags...)
+	connections, diags := slackPluginConnections(ctx, state.Connections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	refreshed := []slackPluginConnectionModel{}
+	for _, conn := range connections {
+		connectionID := conn.ConnectionID.ValueString()
+		pluginConn, err := r.client.GetPluginConnection(hoop.SlackPluginName, connectionID)
+		if hoop.IsNotFound(err) {
+			tflog.Info(ctx, "connection is not bound to the slack plugin, removing it from the state",
+				map[string]any{"connection_id": connectionID})
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Slack Plugin",
+				fmt.Sprintf("Failed reading plugin %q with connection id %q: %v", hoop.SlackPluginName, connectionID, err),
+			)
+			return
+		}
+		// keep the channels unset while the connection uses the default channels
+		if !conn.Channels.IsNull() || !slices.Equal(pluginConn.Config, defaultChannels) {
+			conn.Channels, diags = types.ListValueFrom(ctx, types.StringType, pluginConn.Config)
+			resp.Diagnostics.Append(diags...)
+		}
+		refreshed = append(refreshed, conn)
+	}
+	state.Connections, diags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: slackPluginConnectionAttrTypes}, refreshed)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Create configures the plugin and sets the initial Terraform state.
+// A plugin already configured is not replaced, it must be imported instead.
+func (r *slackPluginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan slackPluginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	currentConfig, err := r.client.GetSlackPluginConfig()
+	if err != nil && !hoop.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error Creating Slack Plugin",
+			fmt.Sprintf("Failed reading plugin %q: %v", hoop.SlackPluginName, err),
+		)
+		return
+	}
+	if currentConfig != nil {
+		resp.Diagnostics.AddError(
+			"Slack Plugin Already Configured",
+			fmt.Sprintf("The plugin %q is already configured. Import it with "+
+				"`terraform import hoop_slack_plugin.<name> %s` to manage it with this resource.", hoop.SlackPluginName, hoop.SlackPluginName),
+		)
+		return
+	}
+
+	slackConfig, err := r.client.UpsertSlackPluginConfig(hoop.SlackPluginConfig{
+		BotToken: plan.BotToken.ValueString(),
+		AppToken: plan.AppToken.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Slack Plugin",
+			fmt.Sprintf("Failed to configure plugin %q: %v", hoop.SlackPluginName, err),
+		)
+		return
+	}
+	plan.ID = types.StringValue(slackConfig.ID)
+
+	resp.Diagnostics.Append(r.upsertConnections(ctx, plan, types.SetNull(types.ObjectType{AttrTypes: slackPluginConnectionAttrTypes}))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state to fully populated data
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *slackPluginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state slackPluginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slackConfig, err := r.client.UpsertSlackPluginConfig(hoop.SlackPluginConfig{
+		BotToken: plan.BotToken.ValueString(),
+		AppToken: plan.AppToken.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Slack Plugin",
+			fmt.Sprintf("Failed to configure plugin %q: %v", hoop.SlackPluginName, err),
+		)
+		return
+	}
+	plan.ID = types.StringValue(slackConfig.ID)
+
+	resp.Diagnostics.Append(r.upsertConnections(ctx, plan, state.Connections)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the connections of the plugin and its configuration.
+func (r *slackPluginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state slackPluginResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connections, diags := slackPluginConnections(ctx, state.Connections)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, conn := range connections {
+		resp.Diagnostics.Append(r.deleteConnection(ctx, conn.ConnectionID.ValueString())...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeletePluginConfig(hoop.SlackPluginName); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Slack Plugin",
+			fmt.Sprintf("Failed to delete plugin config: %v", err),
+		)
+		return
+	}
+}
+
+// ImportState imports the configuration of the Slack plugin, the connections are not imported.
+func (r *slackPluginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// UpgradeState upgrades prior states of the slack plugin resource to the current schema version.
+// The schema is still at its first version, there are no prior states to upgrade.
+func (r *slackPluginResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *slackPluginResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+	r.client = data.client
+}
+
+// upsertConnections binds the planned connections to the plugin and removes
+// the connections of the prior state that are no longer planned.
+func (r *slackPluginResource) upsertConnections(ctx context.Context, plan slackPluginResourceModel, priorConnections types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+	defaultChannels, d := convertListToStringSlice(ctx, plan.DefaultChannels)
+	diags.Append(d...)
+	connections, d := slackPluginConnections(ctx, plan.Connections)
+	diags.Append(d...)
+	priorConns, d := slackPluginConnections(ctx, priorConnections)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	planned := map[string]struct{}{}
+	for _, conn := range connections {
+		connectionID := conn.ConnectionID.ValueString()
+		planned[connectionID] = struct{}{}
+		channels := defaultChannels
+		if !conn.Channels.IsNull() {
+			channels, d = convertListToStringSlice(ctx, conn.Channels)
+			diags.Append(d...)
+			if d.HasError() {
+				return diags
+			}
+		}
+		if _, err := r.client.UpsertPluginConnection(hoop.SlackPluginName, connectionID, channels); err != nil {
+			diags.AddError(
+				"Error Updating Slack Plugin Connection",
+				fmt.Sprintf("Failed to bind connection %q to plugin %q: %v", connectionID, hoop.SlackPluginName, err),
+			)
+			return diags
+		}
+	}
+	for _, conn := range priorConns {
+		if _, ok := planned[conn.ConnectionID.ValueString()]; ok {
+			continue
+		}
+		diags.Append(r.deleteConnection(ctx, conn.ConnectionID.ValueString())...)
+	}
+	return diags
+}
+
+// deleteConnection removes the connection from the plugin, connections already removed are ignored.
+func (r *slackPluginResource) deleteConnection(ctx context.Context, connectionID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	tflog.Info(ctx, "removing connection from the slack plugin", map[string]any{"connection_id": connectionID})
+	err := r.client.DeletePluginConnection(hoop.SlackPluginName, connectionID)
+	if err != nil && !hoop.IsNotFound(err) {
+		diags.AddError(
+			"Error Deleting Slack Plugin Connection",
+			fmt.Sprintf("Failed to remove connection %q from plugin %q: %v", connectionID, hoop.SlackPluginName, err),
+		)
+	}
+	return diags
+}
+
+// slackPluginConnections converts the set of connections to their models, a null set has no connections.
+func slackPluginConnections(ctx context.Context, connections types.Set) ([]slackPluginConnectionModel, diag.Diagnostics) {
+	var models []slackPluginConnectionModel
+	if connections.IsNull() || connections.IsUnknown() {
+		return models, nil
+	}
+	diags := connections.ElementsAs(ctx, &models, false)
+	return models, diags
+}
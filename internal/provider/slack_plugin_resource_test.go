@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hoophq/terraform-provider-hoop/internal/hoop"
+)
+
+func createFakeSlackPluginTestServer() clientFunc {
+	pluginID := "b1a5e0f2-3c4d-4e5f-8a9b-0c1d2e3f4a5b"
+	var pluginConfig *hoop.PluginConfig
+	connections := map[string]*hoop.PluginConnection{}
+	return clientFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		// GET /api/plugins/slack endpoint
+		case req.Method == http.MethodGet && req.URL.Path == "/api/plugins/slack":
+			return httpTestOk(http.StatusOK, &hoop.Plugin{ID: pluginID, Name: "slack", Config: pluginConfig}), nil
+		// PUT /api/plugins/slack/config endpoint
+		case req.Method == http.MethodPut && req.URL.Path == "/api/plugins/slack/config":
+			var envVars map[string]string
+			if err := json.NewDecoder(req.Body).Decode(&envVars); err != nil {
+				return httpTestErr(http.StatusBadRequest, `test: unable to decode request body: %v`, err), nil
+			}
+			pluginConfig = &hoop.PluginConfig{ID: pluginID, EnvVars: envVars}
+			if len(envVars) == 0 {
+				pluginConfig = nil
+			}
+			return httpTestOk(http.StatusOK, &hoop.Plugin{ID: pluginID, Name: "slack", Config: pluginConfig}), nil
+		// /api/plugins/slack/conn/{connection_id} endpoints
+		case strings.HasPrefix(req.URL.Path, "/api/plugins/slack/conn/"):
+			connectionID := strings.TrimPrefix(req.URL.Path, "/api/plugins/slack/conn/")
+			switch req.Method {
+			case http.MethodGet:
+				conn, ok := connections[connectionID]
+				if !ok {
+					return httpTestErr(http.StatusNotFound, `plugin connection %q not found`, connectionID), nil
+				}
+				return httpTestOk(http.StatusOK, conn), nil
+			case http.MethodPut:
+				var body struct {
+					Config []string `json:"config"`
+				}
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return httpTestErr(http.StatusBadRequest, `test: unable to decode request body: %v`, err), nil
+				}
+				connections[connectionID] = &hoop.PluginConnection{PluginID: pluginID, ConnectionID: connectionID, Config: body.Config}
+				return httpTestOk(http.StatusOK, connections[connectionID]), nil
+			case http.MethodDelete:
+				if _, ok := connections[connectionID]; !ok {
+					return httpTestErr(http.StatusNotFound, `plugin connection %q not found`, connectionID), nil
+				}
+				delete(connections, connectionID)
+				return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+			}
+		}
+		return httpTestErr(http.StatusInternalServerError, `test: url path not implemented path: %s, method: %s`, req.URL.Path, req.Method), nil
+	})
+}
+
+func TestSlackPluginResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", createFakeSlackPluginTestServer())()),
+		},
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token = "xapp-1-A08BV"
+  app_token = "xoxb-2136"
+}`,
+				ExpectError: regexp.MustCompile(`must be a Slack bot token`),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token = "xoxb-2136"
+  app_token = "xapp-1-A08BV"
+  connections = [
+    { connection_id = "5001a4a4-9cba-4f2a-9147-d763cd070e0a" }
+  ]
+}`,
+				ExpectError: regexp.MustCompile(`Missing Slack Channels`),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token = "xoxb-2136"
+  app_token = "xapp-1-A08BV"
+  connections = [
+    {
+      connection_id = "5001a4a4-9cba-4f2a-9147-d763cd070e0a"
+      channels      = ["C07PAYMENTS"]
+    },
+    {
+      connection_id = "5001a4a4-9cba-4f2a-9147-d763cd070e0a"
+      channels      = ["G01SECURITY"]
+    }
+  ]
+}`,
+				ExpectError: regexp.MustCompile(`Duplicate Slack Connection`),
+			},
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token        = "xoxb-2136"
+  app_token        = "xapp-1-A08BV"
+  default_channels = ["C082KCG5NJU"]
+}`,
+				ExpectError: regexp.MustCompile(`Missing Slack Connections`),
+			},
+			// Create and Read testing
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token        = "xoxb-2136"
+  app_token        = "xapp-1-A08BV"
+  default_channels = ["C082KCG5NJU"]
+  connections = [
+    { connection_id = "5001a4a4-9cba-4f2a-9147-d763cd070e0a" },
+    {
+      connection_id = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+      channels      = ["C07PAYMENTS", "G01SECURITY"]
+    }
+  ]
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "id", "b1a5e0f2-3c4d-4e5f-8a9b-0c1d2e3f4a5b"),
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "bot_token", "xoxb-2136"),
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "app_token", "xapp-1-A08BV"),
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "connections.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("hoop_slack_plugin.slack", "connections.*", map[string]string{
+						"connection_id": "5001a4a4-9cba-4f2a-9147-d763cd070e0a",
+						"channels.#":    "0",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("hoop_slack_plugin.slack", "connections.*", map[string]string{
+						"connection_id": "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+						"channels.#":    "2",
+						"channels.0":    "C07PAYMENTS",
+					}),
+				),
+			},
+			// Update testing
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token        = "xoxb-4890"
+  app_token        = "xapp-1-A08BV"
+  default_channels = ["C09REVIEWS"]
+  connections = [
+    { connection_id = "5001a4a4-9cba-4f2a-9147-d763cd070e0a" }
+  ]
+}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "bot_token", "xoxb-4890"),
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "default_channels.0", "C09REVIEWS"),
+					resource.TestCheckResourceAttr("hoop_slack_plugin.slack", "connections.#", "1"),
+				),
+			},
+			// ImportState testing, the connections are not imported
+			{
+				ResourceName:                         "hoop_slack_plugin.slack",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "slack",
+				ImportStateVerifyIdentifierAttribute: "id",
+				ImportStateVerifyIgnore:              []string{"default_channels", "connections"},
+			},
+		},
+	})
+}
+
+func TestSlackPluginResourceAlreadyConfigured(t *testing.T) {
+	httpClient := createFakeSlackPluginTestServer()
+	client := hoop.NewClient("http://localhost:8009/api", "xapi-hash", httpClient)
+	if _, err := client.UpsertSlackPluginConfig(hoop.SlackPluginConfig{BotToken: "xoxb-1111", AppToken: "xapp-1-A01"}); err != nil {
+		t.Fatalf("failed configuring slack plugin: %v", err)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", httpClient)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token = "xoxb-2136"
+  app_token = "xapp-1-A08BV"
+}`,
+				ExpectError: regexp.MustCompile(`Slack Plugin Already Configured`),
+			},
+		},
+	})
+}
+
+func TestSlackPluginResourceRemoved(t *testing.T) {
+	slackServer := createFakeSlackPluginTestServer()
+	pluginRemoved := false
+	httpClient := clientFunc(func(req *http.Request) (*http.Response, error) {
+		if pluginRemoved && strings.HasPrefix(req.URL.Path, "/api/plugins/slack") {
+			return httpTestErr(http.StatusNotFound, `plugin %q not found`, hoop.SlackPluginName), nil
+		}
+		return slackServer.Do(req)
+	})
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"hoop": providerserver.NewProtocol6WithError(New("test", httpClient)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "hoop" {
+  api_key = "xapi-hash"
+  api_url = "http://localhost:8009/api"
+}
+
+resource "hoop_slack_plugin" "slack" {
+  bot_token = "xoxb-2136"
+  app_token = "xapp-1-A08BV"
+}`,
+				Check: resource.TestCheckResourceAttrSet("hoop_slack_plugin.slack", "id"),
+			},
+			// the plugin removed outside of terraform is removed from the state
+			{
+				PreConfig:          func() { pluginRemoved = true },
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check:              resource.TestCheckNoResourceAttr("hoop_slack_plugin.slack", "id"),
+			},
+		},
+	})
+}
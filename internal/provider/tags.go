@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// tagsConfig holds the provider configuration applied to the tags of the resources.
+type tagsConfig struct {
+	// defaultTags are merged into the tags of every resource, the tags
+	// of the resource take precedence over them.
+	defaultTags map[string]string
+	// ignoreKeys and ignoreKeyPrefixes match tags managed outside of Terraform,
+	// they are neither reported as drift nor removed when updating a resource.
+	ignoreKeys        map[string]struct{}
+	ignoreKeyPrefixes []string
+}
+
+// providerIgnoreTagsModel maps the ignore_tags attribute of the provider.
+type providerIgnoreTagsModel struct {
+	Keys        types.Set `tfsdk:"keys"`
+	KeyPrefixes types.Set `tfsdk:"key_prefixes"`
+}
+
+func newTagsConfig(ctx context.Context, defaultTags types.Map, ignoreTags types.Object) (cfg tagsConfig, diags diag.Diagnostics) {
+	diags = defaultTags.ElementsAs(ctx, &cfg.defaultTags, false)
+	if diags.HasError() || ignoreTags.IsNull() {
+		return
+	}
+
+	var ignoreTagsModel providerIgnoreTagsModel
+	diags = ignoreTags.As(ctx, &ignoreTagsModel, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return
+	}
+	ignoreKeys, diags := convertSetToStringSlice(ctx, ignoreTagsModel.Keys)
+	if diags.HasError() {
+		return
+	}
+	cfg.ignoreKeys = map[string]struct{}{}
+	for _, key := range ignoreKeys {
+		cfg.ignoreKeys[key] = struct{}{}
+	}
+	cfg.ignoreKeyPrefixes, diags = convertSetToStringSlice(ctx, ignoreTagsModel.KeyPrefixes)
+	return
+}
+
+// isIgnored reports whether the tag key is managed outside of Terraform.
+func (c tagsConfig) isIgnored(key string) bool {
+	if _, ok := c.ignoreKeys[key]; ok {
+		return true
+	}
+	for _, prefix := range c.ignoreKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasIgnoredTags reports whether any tag is managed outside of Terraform.
+func (c tagsConfig) hasIgnoredTags() bool {
+	return len(c.ignoreKeys) > 0 || len(c.ignoreKeyPrefixes) > 0
+}
+
+// merge returns the default tags merged with the tags of the resource.
+func (c tagsConfig) merge(tags map[string]string) map[string]string {
+	if len(c.defaultTags) == 0 && len(tags) == 0 {
+		return nil
+	}
+	merged := map[string]string{}
+	for key, val := range c.defaultTags {
+		merged[key] = val
+	}
+	for key, val := range tags {
+		merged[key] = val
+	}
+	return merged
+}
+
+// withoutIgnored returns the tags that are not managed outside of Terraform.
+func (c tagsConfig) withoutIgnored(tags map[string]string) map[string]string {
+	managed := map[string]string{}
+	for key, val := range tags {
+		if !c.isIgnored(key) {
+			managed[key] = val
+		}
+	}
+	return managed
+}
+
+// withIgnored copies the tags managed outside of Terraform from the
+// remote tags, preventing an update from removing them.
+func (c tagsConfig) withIgnored(tags, remoteTags map[string]string) map[string]string {
+	for key, val := range remoteTags {
+		if _, ok := tags[key]; ok || !c.isIgnored(key) {
+			continue
+		}
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		tags[key] = val
+	}
+	return tags
+}
+
+// resourceTags returns the remote tags that belong to the tags attribute of the resource.
+// The tags inherited from the default tags are removed, unless they're set in the resource.
+func (c tagsConfig) resourceTags(remoteTags, resourceTags map[string]string) map[string]string {
+	tags := map[string]string{}
+	for key, val := range c.withoutIgnored(remoteTags) {
+		_, isResourceTag := resourceTags[key]
+		if defaultVal, ok := c.defaultTags[key]; ok && defaultVal == val && !isResourceTag {
+			continue
+		}
+		tags[key] = val
+	}
+	return tags
+}
+
+// tagsMapValue converts tags to a map value, empty tags are coerced to null.
+func tagsMapValue(ctx context.Context, tags map[string]string) (types.Map, diag.Diagnostics) {
+	if len(tags) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+	return types.MapValueFrom(ctx, types.StringType, tags)
+}
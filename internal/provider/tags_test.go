@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagsConfig(t *testing.T) {
+	cfg := tagsConfig{
+		defaultTags:       map[string]string{"team": "platform", "managed_by": "terraform"},
+		ignoreKeys:        map[string]struct{}{"owner": {}},
+		ignoreKeyPrefixes: []string{"external:"},
+	}
+
+	merged := cfg.merge(map[string]string{"team": "database"})
+	if want := map[string]string{"team": "database", "managed_by": "terraform"}; !reflect.DeepEqual(merged, want) {
+		t.Errorf("merge: expected %v, got %v", want, merged)
+	}
+
+	remoteTags := map[string]string{
+		"team":           "database",
+		"managed_by":     "terraform",
+		"owner":          "security",
+		"external:scan":  "true",
+		"environment":    "production",
+		"unmanaged_team": "sre",
+	}
+	resourceTags := cfg.resourceTags(remoteTags, map[string]string{"team": "database", "environment": "production"})
+	want := map[string]string{"team": "database", "environment": "production", "unmanaged_team": "sre"}
+	if !reflect.DeepEqual(resourceTags, want) {
+		t.Errorf("resourceTags: expected %v, got %v", want, resourceTags)
+	}
+
+	// a default tag changed outside of terraform is reported as drift
+	resourceTags = cfg.resourceTags(map[string]string{"managed_by": "console"}, nil)
+	if want := map[string]string{"managed_by": "console"}; !reflect.DeepEqual(resourceTags, want) {
+		t.Errorf("resourceTags: expected %v, got %v", want, resourceTags)
+	}
+
+	tags := cfg.withIgnored(map[string]string{"team": "database"}, remoteTags)
+	want = map[string]string{"team": "database", "owner": "security", "external:scan": "true"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("withIgnored: expected %v, got %v", want, tags)
+	}
+}
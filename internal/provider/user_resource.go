@@ -32,7 +32,7 @@ func NewUserResource() resource.Resource {
 type userResourceModel struct {
 	ID     types.String `tfsdk:"id"`
 	Email  types.String `tfsdk:"email"`
-	Groups types.List   `tfsdk:"groups"`
+	Groups types.Set    `tfsdk:"groups"`
 	Status types.String `tfsdk:"status"`
 }
 
@@ -50,6 +50,7 @@ func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest,
 func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manage user and group resources. Do not use this terraform resource when managing groups via Identity Provider. Make sure to work with this resource only with the gateway version 1.39.1 and onwards.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "The unique identifier of the resource.",
@@ -65,7 +66,7 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"groups": schema.ListAttribute{
+			"groups": schema.SetAttribute{
 				Description: "Groups the user belongs to.",
 				Required:    true,
 				ElementType: types.StringType,
@@ -100,20 +101,9 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	tflog.Info(ctx, fmt.Sprintf("Read user %q with ID %q, groups=%#v", user.Email, user.ID, user.Groups))
 
-	// Extract current state groups to preserve ordering
-	var stateGroups []string
-	diags = currentState.Groups.ElementsAs(ctx, &stateGroups, false)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// Reorder API groups to match state order, avoiding spurious diffs
-	orderedGroups := reorderGroups(stateGroups, user.Groups)
-
 	currentState.ID = types.StringValue(user.ID)
 	currentState.Status = types.StringValue(user.Status)
-	currentState.Groups, diags = types.ListValueFrom(ctx, types.StringType, orderedGroups)
+	currentState.Groups, diags = types.SetValueFrom(ctx, types.StringType, user.Groups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Groups",
@@ -140,7 +130,7 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	userGroups, diags := convertListToStringSlice(ctx, plan.Groups)
+	userGroups, diags := convertSetToStringSlice(ctx, plan.Groups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Groups to Slice",
@@ -176,7 +166,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	userGroups, diags := convertListToStringSlice(ctx, plan.Groups)
+	userGroups, diags := convertSetToStringSlice(ctx, plan.Groups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Groups to Slice",
@@ -193,7 +183,7 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 	plan.Status = types.StringValue(userResp.Status)
-	plan.Groups, diags = types.ListValueFrom(ctx, types.StringType, userResp.Groups)
+	plan.Groups, diags = types.SetValueFrom(ctx, types.StringType, userResp.Groups)
 	if diags.HasError() {
 		resp.Diagnostics.AddError(
 			"Error Converting Groups",
@@ -239,38 +229,14 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*hoop.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hoop.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
-	r.client = client
-}
-
-// reorderGroups returns apiGroups reordered to match the order in stateGroups.
-// Groups present in the API but not in the state are appended at the end.
-func reorderGroups(stateGroups, apiGroups []string) []string {
-	apiSet := make(map[string]struct{}, len(apiGroups))
-	for _, g := range apiGroups {
-		apiSet[g] = struct{}{}
-	}
-
-	ordered := make([]string, 0, len(apiGroups))
-	for _, g := range stateGroups {
-		if _, ok := apiSet[g]; ok {
-			ordered = append(ordered, g)
-			delete(apiSet, g)
-		}
-	}
-	// Append any groups the API returned that weren't in the state
-	for _, g := range apiGroups {
-		if _, ok := apiSet[g]; ok {
-			ordered = append(ordered, g)
-		}
-	}
-	return ordered
+	r.client = data.client
 }
@@ -89,8 +89,8 @@ resource "hoop_user" "john-hoop-dev" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "email", "john@hoop.dev"),
 					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "status", "active"),
-					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "groups.0", "engineering"),
-					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "groups.1", "devops"),
+					resource.TestCheckTypeSetElemAttr("hoop_user.john-hoop-dev", "groups.*", "engineering"),
+					resource.TestCheckTypeSetElemAttr("hoop_user.john-hoop-dev", "groups.*", "devops"),
 				),
 			},
 			// Update Testing
@@ -116,12 +116,12 @@ resource "hoop_user" "john-hoop-dev" {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "email", "john@hoop.dev"),
 					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "status", "inactive"),
-					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "groups.0", "engineering"),
-					resource.TestCheckResourceAttr("hoop_user.john-hoop-dev", "groups.1", "devops"),
+					resource.TestCheckTypeSetElemAttr("hoop_user.john-hoop-dev", "groups.*", "engineering"),
+					resource.TestCheckTypeSetElemAttr("hoop_user.john-hoop-dev", "groups.*", "devops"),
 					resource.TestCheckResourceAttr("hoop_user.billy-hoop-dev", "email", "billy@hoop.dev"),
 					resource.TestCheckResourceAttr("hoop_user.billy-hoop-dev", "status", "active"),
-					resource.TestCheckResourceAttr("hoop_user.billy-hoop-dev", "groups.0", "banking"),
-					resource.TestCheckResourceAttr("hoop_user.billy-hoop-dev", "groups.1", "finance"),
+					resource.TestCheckTypeSetElemAttr("hoop_user.billy-hoop-dev", "groups.*", "banking"),
+					resource.TestCheckTypeSetElemAttr("hoop_user.billy-hoop-dev", "groups.*", "finance"),
 
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hoop_user.john-hoop-dev", "id"),
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &userResource{}
+
+// userResourceModelV0 maps the schema version 0 of the user resource,
+// where groups was a list.
+type userResourceModelV0 struct {
+	ID     types.String `tfsdk:"id"`
+	Email  types.String `tfsdk:"email"`
+	Groups types.List   `tfsdk:"groups"`
+	Status types.String `tfsdk:"status"`
+}
+
+// userSchemaV0 is the schema version 0 of the user resource.
+// It must not be changed, it's only used to decode prior states.
+func userSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":     schema.StringAttribute{Computed: true},
+			"email":  schema.StringAttribute{Required: true},
+			"groups": schema.ListAttribute{Required: true, ElementType: types.StringType},
+			"status": schema.StringAttribute{Required: true},
+		},
+	}
+}
+
+// UpgradeState upgrades prior states of the user resource to the current schema version.
+func (r *userResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: userSchemaV0(),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState userResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState, diags := upgradeUserStateV0(ctx, priorState)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+	}
+}
+
+// upgradeUserStateV0 converts the groups list of the schema version 0 to a set.
+func upgradeUserStateV0(ctx context.Context, prior userResourceModelV0) (state userResourceModel, diags diag.Diagnostics) {
+	state = userResourceModel{
+		ID:     prior.ID,
+		Email:  prior.Email,
+		Status: prior.Status,
+	}
+	state.Groups, diags = convertListToSet(ctx, prior.Groups)
+	return
+}
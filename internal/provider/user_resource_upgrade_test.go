@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+func TestUserResourceUpgradeStateV0(t *testing.T) {
+	var state userResourceModel
+	upgradeTestState(t, NewUserResource, 0, `{
+  "id": "a6a1fb1c-f35b-45f4-8f9c-1d3a6e8cf5a0",
+  "email": "john@hoop.dev",
+  "groups": ["engineering", "devops", "engineering"],
+  "status": "active"
+}`, &state)
+
+	if state.ID.ValueString() != "a6a1fb1c-f35b-45f4-8f9c-1d3a6e8cf5a0" {
+		t.Errorf("expected id to be preserved, got %v", state.ID)
+	}
+	if state.Email.ValueString() != "john@hoop.dev" || state.Status.ValueString() != "active" {
+		t.Errorf("expected email and status to be preserved, got %v, %v", state.Email, state.Status)
+	}
+
+	groups, diags := convertSetToStringSlice(context.Background(), state.Groups)
+	if diags.HasError() {
+		t.Fatalf("failed converting groups: %v", diags)
+	}
+	sort.Strings(groups)
+	if len(groups) != 2 || groups[0] != "devops" || groups[1] != "engineering" {
+		t.Errorf("expected groups to be a set of [devops engineering], got %v", groups)
+	}
+}
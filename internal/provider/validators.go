@@ -3,9 +3,16 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 )
@@ -17,6 +24,13 @@ var NonEmptyListValidator = []validator.List{
 	),
 }
 
+var NonEmptySetValidator = []validator.Set{
+	setvalidator.SizeAtLeast(1),
+	setvalidator.ValueStringsAre(
+		stringvalidator.LengthAtLeast(1),
+	),
+}
+
 var NonEmptyMapValidator = []validator.Map{
 	mapvalidator.SizeAtLeast(1),
 	mapvalidator.ValueStringsAre(
@@ -51,3 +65,173 @@ var ScoreThresholdValidator = []validator.Float64{
 var UserStatusValidator = []validator.String{
 	stringvalidator.OneOf("active", "inactive"),
 }
+
+var TimeOfDayValidator = []validator.String{
+	stringvalidator.RegexMatches(regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`), "must be a time of the day in the format HH:MM"),
+}
+
+var PositiveInt64Validator = []validator.Int64{
+	int64validator.AtLeast(1),
+}
+
+var EntityTypeNameValidator = []validator.String{
+	stringvalidator.RegexMatches(regexp.MustCompile(`^[^\p{Ll}\s]([^\p{Ll}]*[^\p{Ll}\s])?$`),
+		"must be uppercase, without leading or trailing spaces"),
+}
+
+var SlackBotTokenValidator = []validator.String{
+	stringvalidator.RegexMatches(regexp.MustCompile(`^xoxb-\S+$`), "must be a Slack bot token, starting with xoxb-"),
+}
+
+var SlackAppTokenValidator = []validator.String{
+	stringvalidator.RegexMatches(regexp.MustCompile(`^xapp-\S+$`), "must be a Slack app-level token, starting with xapp-"),
+}
+
+var SlackChannelsValidator = []validator.List{
+	listvalidator.SizeAtLeast(1),
+	listvalidator.UniqueValues(),
+	listvalidator.ValueStringsAre(
+		stringvalidator.RegexMatches(regexp.MustCompile(`^[CG][A-Z0-9]+$`), "must be the id of a Slack channel, e.g.: C082KCG5NJU"),
+	),
+}
+
+var PythonRegexValidator = []validator.String{
+	pythonRegexValidator{},
+}
+
+// pythonRegexValidator validates regular expressions evaluated by the python engine of the gateway.
+// The patterns are compiled with the Go engine, which supports a subset of the python syntax,
+// the patterns using constructs only supported by python are not compiled and emit a warning instead.
+type pythonRegexValidator struct{}
+
+func (v pythonRegexValidator) Description(_ context.Context) string {
+	return "value must be a valid python regular expression"
+}
+
+func (v pythonRegexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pythonRegexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	pattern := req.ConfigValue.ValueString()
+	goPattern, pythonOnly, unsupported := scanRegexConstructs(pattern)
+	if len(unsupported) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Python Regular Expression",
+			fmt.Sprintf("The pattern %q uses constructs not supported by python: %s", pattern, strings.Join(unsupported, ", ")),
+		)
+		return
+	}
+	if len(pythonOnly) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Python Regular Expression Not Validated",
+			fmt.Sprintf("The pattern %q uses constructs that could not be validated when planning: %s. "+
+				"Make sure it's a valid python regular expression, invalid patterns fail when masking the sessions of the connections.",
+				pattern, strings.Join(pythonOnly, ", ")),
+		)
+		return
+	}
+	if _, err := regexp.Compile(goPattern); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Python Regular Expression",
+			fmt.Sprintf("The pattern %q is invalid: %v", pattern, err),
+		)
+	}
+}
+
+// scanRegexConstructs returns the constructs of the pattern that are only supported by python
+// and the ones that are supported by Go but not by python. The returned Go pattern translates
+// the python constructs with a different syntax in Go.
+func scanRegexConstructs(pattern string) (goPattern string, pythonOnly, unsupported []string) {
+	var goPatternBuilder strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		rest := pattern[i:]
+		goPatternBuilder.WriteByte(rest[0])
+		switch {
+		case strings.HasPrefix(rest, `\`) && len(rest) > 1:
+			switch c := rest[1]; {
+			case c >= '1' && c <= '9' && !inClass:
+				pythonOnly = append(pythonOnly, "backreference "+rest[:2])
+			case c == 'p' || c == 'P':
+				unsupported = append(unsupported, "unicode class "+rest[:2])
+			case c == 'Q' || c == 'E':
+				unsupported = append(unsupported, "quoting "+rest[:2])
+			case c == 'z':
+				unsupported = append(unsupported, `end of text \z, use \Z instead`)
+			case c == 'N':
+				pythonOnly = append(pythonOnly, `named unicode character \N{`)
+			case c == 'x' && strings.HasPrefix(rest[2:], "{"):
+				unsupported = append(unsupported, `hexadecimal escape \x{, use \xhh or \uhhhh instead`)
+			}
+			// skip the escaped character, the end of text is \Z in python and \z in Go
+			if rest[1] == 'Z' {
+				goPatternBuilder.WriteByte('z')
+			} else {
+				goPatternBuilder.WriteByte(rest[1])
+			}
+			i++
+		case inClass:
+			inClass = rest[0] != ']'
+		case strings.HasPrefix(rest, "[[:"):
+			unsupported = append(unsupported, "POSIX class [[:")
+			inClass = true
+		case rest[0] == '[':
+			inClass = true
+			// a closing bracket right after the opening one is a literal
+			if strings.HasPrefix(rest, "[]") || strings.HasPrefix(rest, "[^]") {
+				closing := strings.Index(rest, "]")
+				goPatternBuilder.WriteString(rest[1 : closing+1])
+				i += closing
+			}
+		case strings.HasPrefix(rest, "(?="), strings.HasPrefix(rest, "(?!"):
+			pythonOnly = append(pythonOnly, "lookahead "+rest[:3])
+		case strings.HasPrefix(rest, "(?<="), strings.HasPrefix(rest, "(?<!"):
+			pythonOnly = append(pythonOnly, "lookbehind "+rest[:4])
+		case strings.HasPrefix(rest, "(?<"):
+			unsupported = append(unsupported, "named group (?<name>, use (?P<name> instead")
+		case strings.HasPrefix(rest, "(?P="):
+			pythonOnly = append(pythonOnly, "named backreference (?P=")
+		case strings.HasPrefix(rest, "(?("):
+			pythonOnly = append(pythonOnly, "conditional group (?(")
+		case strings.HasPrefix(rest, "(?>"):
+			pythonOnly = append(pythonOnly, "atomic group (?>")
+		case strings.HasPrefix(rest, "(?#"):
+			pythonOnly = append(pythonOnly, "comment (?#")
+		case strings.HasPrefix(rest, "(?"):
+			flags := regexInlineFlags(rest)
+			if strings.ContainsAny(flags, "aLux") {
+				pythonOnly = append(pythonOnly, "inline flags (?"+flags)
+			}
+			if strings.Contains(flags, "U") {
+				unsupported = append(unsupported, "ungreedy flag (?U")
+			}
+			// python only accepts the flags applying to the whole pattern at its start
+			if flags != "" && i > 0 && rest[2+len(flags)] == ')' {
+				unsupported = append(unsupported, "inline flags (?"+flags+") not at the start of the pattern")
+			}
+		case len(rest) > 1 && strings.ContainsRune("*+?}", rune(rest[0])) && rest[1] == '+':
+			pythonOnly = append(pythonOnly, "possessive quantifier "+rest[:2])
+			goPatternBuilder.WriteByte(rest[1])
+			i++
+		}
+	}
+	return goPatternBuilder.String(), pythonOnly, unsupported
+}
+
+// regexInlineFlags returns the flags of the inline flags group starting the pattern,
+// e.g.: `x` for `(?x)` and `i-s` for `(?i-s:...)`, or an empty string for other groups.
+func regexInlineFlags(pattern string) string {
+	flags := strings.TrimPrefix(pattern, "(?")
+	end := strings.IndexFunc(flags, func(r rune) bool { return !strings.ContainsRune("aiLmsuxU-", r) })
+	if end <= 0 || (flags[end] != ')' && flags[end] != ':') {
+		return ""
+	}
+	return flags[:end]
+}